@@ -0,0 +1,358 @@
+package couchdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrBulkWriterClosed is returned when writing to a closed BulkWriter.
+var ErrBulkWriterClosed = errors.New("couchdb: bulk writer is closed")
+
+// BulkWriterOptions configures a BulkWriter.
+type BulkWriterOptions struct {
+	// BatchSize is the maximum number of documents per _bulk_docs request.
+	// Defaults to 500.
+	BatchSize int
+
+	// BatchBytes is the maximum size of the encoded documents per request.
+	// A single document larger than BatchBytes is sent on its own.
+	// Defaults to 4 MiB.
+	BatchBytes int
+
+	// Concurrency is the number of requests allowed in flight at once. Write
+	// blocks while all of them are busy. Defaults to 4.
+	Concurrency int
+
+	// RequestTimeout bounds every _bulk_docs request. Requests are detached
+	// from the cancellation of the caller's context, so this is what stops a
+	// hung server from holding a request slot forever. Defaults to 5 minutes.
+	RequestTimeout time.Duration
+
+	// OnResult, if set, is called with the outcome of every document. It is
+	// called from the goroutines that send the batches and must be safe for
+	// concurrent use.
+	OnResult func(BulkWriterResult)
+
+	// Results, if set, receives the outcome of every document. A full channel
+	// blocks the sending goroutine and, in turn, Write. The channel is not
+	// closed by the writer.
+	Results chan<- BulkWriterResult
+}
+
+// BulkWriterResult is the outcome of a document written by a BulkWriter.
+type BulkWriterResult struct {
+	Doc  map[string]any
+	Item BulkDocItem
+	// Err is the per-document write error, or the error of the whole
+	// request if the batch could not be written.
+	Err error
+}
+
+// BulkWriterStats counts the documents handled by a BulkWriter.
+type BulkWriterStats struct {
+	Batches int
+	Written int
+	Failed  int
+}
+
+// BulkWriter loads documents through _bulk_docs in batches. Documents are
+// accepted one at a time, grouped into batches by count and size, and sent by
+// a bounded number of concurrent requests. When the server falls behind, Write
+// blocks until a request completes.
+//
+// A BulkWriter is safe for concurrent use. Close must be called to send the
+// last batch.
+type BulkWriter struct {
+	client  *Client
+	dbName  string
+	options BulkWriterOptions
+	opts    []RequestOption
+
+	mu     sync.Mutex
+	batch  []bulkWriterDoc
+	size   int
+	closed bool
+	stats  BulkWriterStats
+
+	slots    chan struct{}
+	inflight int           // Guarded by mu
+	idle     chan struct{} // Closed when inflight drops to 0; guarded by mu
+}
+
+type bulkWriterDoc struct {
+	doc  map[string]any
+	data json.RawMessage
+}
+
+// NewBulkWriter creates a BulkWriter for a database. opts are applied to every
+// request made on its behalf.
+// Example usage:
+//
+//	w := NewBulkWriter(client, "events", &BulkWriterOptions{
+//		Concurrency: 8,
+//		OnResult: func(r BulkWriterResult) {
+//			if r.Err != nil {
+//				log.Printf("%s: %v", r.Item.ID, r.Err)
+//			}
+//		},
+//	})
+//	for _, doc := range docs {
+//		if err := w.Write(ctx, doc); err != nil {
+//			return err
+//		}
+//	}
+//	return w.Close(ctx)
+func NewBulkWriter(client *Client, dbName string, options *BulkWriterOptions, opts ...RequestOption) *BulkWriter {
+	var o BulkWriterOptions
+	if options != nil {
+		o = *options
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 500
+	}
+	if o.BatchBytes <= 0 {
+		o.BatchBytes = 4 << 20
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	if o.RequestTimeout <= 0 {
+		o.RequestTimeout = 5 * time.Minute
+	}
+
+	return &BulkWriter{
+		client:  client,
+		dbName:  dbName,
+		options: o,
+		opts:    opts,
+		slots:   make(chan struct{}, o.Concurrency),
+	}
+}
+
+// Write adds a document to the current batch, sending the batch once it is
+// full. It blocks while Concurrency requests are in flight; ctx only bounds
+// that wait. Batches are sent in the background, detached from the
+// cancellation of ctx and bounded by RequestTimeout instead, so that a
+// request-scoped ctx ending after Write returns does not fail the documents of
+// other callers in the same batch.
+func (w *BulkWriter) Write(ctx context.Context, doc map[string]any) error {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal document: %w", err)
+	}
+
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return ErrBulkWriterClosed
+	}
+
+	var full []bulkWriterDoc
+	if len(w.batch) > 0 && w.size+len(data) > w.options.BatchBytes {
+		full = w.take()
+	}
+	w.batch = append(w.batch, bulkWriterDoc{doc: doc, data: data})
+	w.size += len(data)
+	if full == nil && len(w.batch) >= w.options.BatchSize {
+		full = w.take()
+	}
+	w.mu.Unlock()
+
+	if full == nil {
+		return nil
+	}
+
+	return w.send(ctx, full)
+}
+
+// Flush sends the current batch, even if it is not full, and waits for all
+// requests in flight to complete. If ctx is done first, Flush returns its
+// error and the requests carry on in the background until they complete or
+// time out.
+func (w *BulkWriter) Flush(ctx context.Context) error {
+	w.mu.Lock()
+	batch := w.take()
+	w.mu.Unlock()
+
+	if len(batch) > 0 {
+		if err := w.send(ctx, batch); err != nil {
+			return err
+		}
+	}
+
+	w.mu.Lock()
+	if w.inflight == 0 {
+		w.mu.Unlock()
+		return nil
+	}
+	idle := w.idle
+	w.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes the writer and rejects further writes. Like Flush, it stops
+// waiting for the requests in flight once ctx is done.
+func (w *BulkWriter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	w.closed = true
+	w.mu.Unlock()
+
+	return w.Flush(ctx)
+}
+
+// Stats returns the number of batches sent and documents written or failed
+// so far.
+func (w *BulkWriter) Stats() BulkWriterStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.stats
+}
+
+// take removes and returns the current batch. w.mu must be held.
+func (w *BulkWriter) take() []bulkWriterDoc {
+	batch := w.batch
+	w.batch = nil
+	w.size = 0
+	return batch
+}
+
+// send waits for a free request slot and writes batch in the background. The
+// request keeps the values of ctx, such as trace spans, but not its
+// cancellation; it is bounded by RequestTimeout instead.
+func (w *BulkWriter) send(ctx context.Context, batch []bulkWriterDoc) error {
+	select {
+	case w.slots <- struct{}{}:
+	case <-ctx.Done():
+		w.report(batch, nil, ctx.Err())
+		return ctx.Err()
+	}
+
+	w.mu.Lock()
+	if w.inflight == 0 {
+		w.idle = make(chan struct{})
+	}
+	w.inflight++
+	w.mu.Unlock()
+
+	go func() {
+		defer w.done()
+		defer func() { <-w.slots }()
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), w.options.RequestTimeout)
+		defer cancel()
+
+		items, err := w.write(ctx, batch)
+
+		w.mu.Lock()
+		w.stats.Batches++
+		w.mu.Unlock()
+
+		w.report(batch, items, err)
+	}()
+
+	return nil
+}
+
+// done marks a request as complete, waking Flush once none are left.
+func (w *BulkWriter) done() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.inflight--
+	if w.inflight == 0 {
+		close(w.idle)
+	}
+}
+
+// write sends batch to _bulk_docs.
+func (w *BulkWriter) write(ctx context.Context, batch []bulkWriterDoc) (BulkDocsResponse, error) {
+	path := fmt.Sprintf("/%s/_bulk_docs", url.PathEscape(w.dbName))
+
+	var body bytes.Buffer
+	body.WriteString(`{"docs":[`)
+	for i, d := range batch {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		body.Write(d.data)
+	}
+	body.WriteString(`]}`)
+
+	resp, err := w.client.doRequest(ctx, operation{"DatabaseService", "BulkDocs"}, http.MethodPost, path, bytes.NewReader(body.Bytes()), w.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write batch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+		}
+		return nil, fmt.Errorf("failed to write batch: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var bulkResp BulkDocsResponse
+	if err := json.Unmarshal(respBody, &bulkResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if len(bulkResp) != len(batch) {
+		return nil, fmt.Errorf("bulk docs returned %d results for %d documents", len(bulkResp), len(batch))
+	}
+
+	return bulkResp, nil
+}
+
+// report delivers the outcome of every document of a batch. If err is set
+// the whole batch failed.
+func (w *BulkWriter) report(batch []bulkWriterDoc, items BulkDocsResponse, err error) {
+	results := make([]BulkWriterResult, len(batch))
+	failed := 0
+	for i, d := range batch {
+		result := BulkWriterResult{Doc: d.doc, Err: err}
+		if err == nil {
+			result.Item = items[i]
+			result.Err = items[i].Err()
+		} else if id, ok := d.doc["_id"].(string); ok {
+			result.Item = BulkDocItem{ID: id}
+		}
+		if result.Err != nil {
+			failed++
+		}
+		results[i] = result
+	}
+
+	w.mu.Lock()
+	w.stats.Written += len(batch) - failed
+	w.stats.Failed += failed
+	w.mu.Unlock()
+
+	for _, result := range results {
+		if w.options.OnResult != nil {
+			w.options.OnResult(result)
+		}
+		if w.options.Results != nil {
+			w.options.Results <- result
+		}
+	}
+}
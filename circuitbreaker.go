@@ -0,0 +1,300 @@
+package couchdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is matched by errors.Is for requests rejected by an open
+// circuit breaker.
+var ErrCircuitOpen = errors.New("couchdb: circuit breaker is open")
+
+// CircuitOpenError is returned when a request is rejected because the circuit
+// breaker considers the server unhealthy.
+type CircuitOpenError struct {
+	// RetryAt is when the breaker will next probe the server.
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s until %s", ErrCircuitOpen, e.RetryAt.Format(time.RFC3339))
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects requests with a CircuitOpenError.
+	CircuitOpen
+	// CircuitHalfOpen probes /_up before letting requests through again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(s))
+}
+
+// CircuitBreaker configures the client's circuit breaker.
+// A request counts as failed when it gets no response or a 5xx response.
+type CircuitBreaker struct {
+	// ConsecutiveFailures opens the circuit after this many failures in a row.
+	// Defaults to 5.
+	ConsecutiveFailures int
+
+	// FailureRate opens the circuit when the ratio of failed requests within
+	// Window exceeds it, e.g. 0.5. Zero disables rate-based tripping.
+	FailureRate float64
+
+	// MinRequests is the number of requests required within Window before
+	// FailureRate is evaluated. Defaults to 20.
+	MinRequests int
+
+	// Window is the period over which FailureRate is measured. Defaults to 30s.
+	Window time.Duration
+
+	// OpenTimeout is how long the circuit stays open before probing /_up.
+	// Defaults to 30s.
+	OpenTimeout time.Duration
+
+	// ProbeTimeout bounds the /_up probe. Defaults to 10s.
+	ProbeTimeout time.Duration
+
+	// OnStateChange, if set, is called whenever the breaker changes state.
+	// It is called synchronously and must not block.
+	OnStateChange func(from, to CircuitState)
+}
+
+// WithCircuitBreaker makes the client fail fast while CouchDB is unhealthy.
+// Once tripped, requests are rejected with a *CircuitOpenError until
+// OpenTimeout has passed; the next request then probes GET /_up and the circuit
+// closes again only if the probe succeeds.
+//
+// The circuit breaker is installed as a middleware at the position of the
+// option, so the requests it sees depend on the order of the options:
+// middleware added before it, such as WithMetrics or WithTracer, observes
+// rejected requests as errors, while middleware added after it only sees the
+// requests let through, and their errors count as failures. The probe is sent
+// through the middleware added after the breaker, with the headers, and thus
+// the credentials, of the request that triggered it.
+// Example usage:
+//
+//	client := NewClient("http://localhost:5984", WithCircuitBreaker(CircuitBreaker{
+//		ConsecutiveFailures: 3,
+//		OpenTimeout:         10 * time.Second,
+//	}))
+func WithCircuitBreaker(config CircuitBreaker) ClientOption {
+	return func(c *Client) {
+		if config.ConsecutiveFailures <= 0 {
+			config.ConsecutiveFailures = 5
+		}
+		if config.MinRequests <= 0 {
+			config.MinRequests = 20
+		}
+		if config.Window <= 0 {
+			config.Window = 30 * time.Second
+		}
+		if config.OpenTimeout <= 0 {
+			config.OpenTimeout = 30 * time.Second
+		}
+		if config.ProbeTimeout <= 0 {
+			config.ProbeTimeout = 10 * time.Second
+		}
+
+		c.breaker = &breaker{
+			config:      config,
+			windowStart: time.Now(),
+		}
+		c.middleware = append(c.middleware, c.breaker.middleware(c))
+	}
+}
+
+// CircuitState returns the state of the client's circuit breaker.
+// It returns CircuitClosed if no circuit breaker is configured.
+func (c *Client) CircuitState() CircuitState {
+	if c.breaker == nil {
+		return CircuitClosed
+	}
+
+	c.breaker.mu.Lock()
+	defer c.breaker.mu.Unlock()
+
+	return c.breaker.state
+}
+
+type breaker struct {
+	config CircuitBreaker
+
+	mu          sync.Mutex
+	state       CircuitState
+	consecutive int
+	requests    int
+	failures    int
+	windowStart time.Time
+	openUntil   time.Time
+}
+
+func (b *breaker) middleware(c *Client) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := b.allow(req, c, next); err != nil {
+				return nil, err
+			}
+
+			resp, err := next.RoundTrip(req)
+			if err != nil && req.Context().Err() == nil {
+				b.record(false)
+			} else if err == nil {
+				b.record(resp.StatusCode < http.StatusInternalServerError)
+			}
+
+			return resp, err
+		})
+	}
+}
+
+// allow decides whether a request may proceed, probing the server if the
+// open timeout has elapsed.
+func (b *breaker) allow(req *http.Request, c *Client, next http.RoundTripper) error {
+	b.mu.Lock()
+	switch b.state {
+	case CircuitClosed:
+		b.mu.Unlock()
+		return nil
+	case CircuitHalfOpen:
+		retryAt := b.openUntil
+		b.mu.Unlock()
+		return &CircuitOpenError{RetryAt: retryAt}
+	}
+
+	if time.Now().Before(b.openUntil) {
+		retryAt := b.openUntil
+		b.mu.Unlock()
+		return &CircuitOpenError{RetryAt: retryAt}
+	}
+
+	// This request becomes the probe; everyone else keeps failing fast.
+	b.setState(CircuitHalfOpen)
+	b.mu.Unlock()
+
+	err := b.probe(req, c, next)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err != nil {
+		b.trip()
+		return &CircuitOpenError{RetryAt: b.openUntil}
+	}
+
+	b.reset()
+	b.setState(CircuitClosed)
+	return nil
+}
+
+// probe checks /_up through next, which for a multi-node client fails over
+// to the other nodes. The probe is detached from the cancellation of the
+// triggering request, whose caller giving up says nothing about the server.
+func (b *breaker) probe(req *http.Request, c *Client, next http.RoundTripper) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(req.Context()), b.config.ProbeTimeout)
+	defer cancel()
+
+	ctx = context.WithValue(ctx, requestInfoKey{}, newRequestInfo(operation{"Client", "CircuitBreakerProbe"}, http.MethodGet, "/_up"))
+	probe, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/_up", nil)
+	if err != nil {
+		return err
+	}
+	probe.Header = req.Header.Clone()
+
+	resp, err := next.RoundTrip(probe)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check failed with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// record accounts for the outcome of a request that was let through.
+func (b *breaker) record(ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(b.windowStart) > b.config.Window {
+		b.requests, b.failures = 0, 0
+		b.windowStart = now
+	}
+
+	b.requests++
+	if ok {
+		b.consecutive = 0
+		return
+	}
+	b.failures++
+	b.consecutive++
+
+	if b.state != CircuitClosed {
+		return
+	}
+
+	if b.consecutive >= b.config.ConsecutiveFailures {
+		b.trip()
+		return
+	}
+
+	if b.config.FailureRate > 0 && b.requests >= b.config.MinRequests &&
+		float64(b.failures)/float64(b.requests) > b.config.FailureRate {
+		b.trip()
+	}
+}
+
+// trip opens the circuit. b.mu must be held.
+func (b *breaker) trip() {
+	b.openUntil = time.Now().Add(b.config.OpenTimeout)
+	b.setState(CircuitOpen)
+}
+
+// reset clears the failure counters. b.mu must be held.
+func (b *breaker) reset() {
+	b.consecutive = 0
+	b.requests, b.failures = 0, 0
+	b.windowStart = time.Now()
+}
+
+// setState transitions the breaker. b.mu must be held.
+func (b *breaker) setState(state CircuitState) {
+	if b.state == state {
+		return
+	}
+
+	from := b.state
+	b.state = state
+	if b.config.OnStateChange != nil {
+		b.config.OnStateChange(from, state)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // Authenticator defines the interface for different authentication methods.
@@ -71,8 +72,17 @@ func (a *JWTAuthenticator) Authenticate(req *http.Request) error {
 
 // Client is an HTTP client for interacting with a CouchDB server.
 type Client struct {
-	baseURL string
-	client  *http.Client
+	baseURL    string
+	client     *http.Client
+	middleware []Middleware
+	transport  http.RoundTripper
+	metrics    Metrics
+	breaker    *breaker
+
+	// Multi-node routing, see NewMultiNodeClient.
+	nodes               *nodePool
+	balancing           LoadBalancing
+	healthCheckInterval time.Duration
 }
 
 // ClientOption is a functional option for configuring CouchDBClient.
@@ -145,6 +155,8 @@ func NewClient(baseURL string, opts ...ClientOption) *Client {
 		opt(client)
 	}
 
+	client.transport = client.buildTransport()
+
 	return client
 }
 
@@ -154,8 +166,22 @@ type ErrorResponse struct {
 	Reason string `json:"reason"`
 }
 
-// doRequest performs an HTTP request with optional authentication.
-func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader, opts ...RequestOption) (*http.Response, error) {
+// doRequest performs an HTTP request with optional authentication. op names
+// the service method the request is made for.
+func (c *Client) doRequest(ctx context.Context, op operation, method, path string, body io.Reader, opts ...RequestOption) (*http.Response, error) {
+	return c.do(ctx, op, method, path, body, nil, opts)
+}
+
+// doRequestWithHeader performs an HTTP request with additional headers,
+// such as Accept for endpoints that negotiate the response format.
+func (c *Client) doRequestWithHeader(ctx context.Context, op operation, method, path string, body io.Reader, header http.Header, opts ...RequestOption) (*http.Response, error) {
+	return c.do(ctx, op, method, path, body, header, opts)
+}
+
+// do is the common implementation of doRequest and doRequestWithHeader.
+func (c *Client) do(ctx context.Context, op operation, method, path string, body io.Reader, header http.Header, opts []RequestOption) (*http.Response, error) {
+	ctx = context.WithValue(ctx, requestInfoKey{}, newRequestInfo(op, method, path))
+
 	reqURL := fmt.Sprintf("%s%s", c.baseURL, path)
 	req, err := http.NewRequestWithContext(ctx, method, reqURL, body)
 	if err != nil {
@@ -172,8 +198,18 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	return c.transport.RoundTrip(req)
+}
 
-	return c.client.Do(req)
+// ClusterSetup returns the ClusterSetupService.
+func (c *Client) ClusterSetup() *ClusterSetupService {
+	return &ClusterSetupService{client: c}
 }
 
 // Configuration returns the ConfigurationService.
@@ -196,6 +232,11 @@ func (c *Client) Documents() *DocumentService {
 	return &DocumentService{client: c}
 }
 
+// Replicator returns the ReplicatorService.
+func (c *Client) Replicator() *ReplicatorService {
+	return &ReplicatorService{client: c}
+}
+
 // Security returns the SecurityService.
 func (c *Client) Security() *SecurityService {
 	return &SecurityService{client: c}
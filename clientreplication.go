@@ -0,0 +1,595 @@
+package couchdb
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ReplicationCheckpoint represents the _local document in which a replication
+// records its progress, on both the source and the target. The format matches
+// the checkpoints written by the CouchDB replicator.
+type ReplicationCheckpoint struct {
+	ID                   string               `json:"_id"`
+	Rev                  string               `json:"_rev,omitempty"`
+	SessionID            string               `json:"session_id"`
+	SourceLastSeq        any                  `json:"source_last_seq"`
+	ReplicationIDVersion int                  `json:"replication_id_version"`
+	History              []ReplicationHistory `json:"history"`
+}
+
+// ReplicationPeer is the part shared by both ends of a client-side replication.
+type ReplicationPeer interface {
+	// PeerID returns a stable identifier of the endpoint, used to derive the
+	// replication ID.
+	PeerID() string
+
+	// GetCheckpoint returns the checkpoint document with the given ID
+	// (without the "_local/" prefix), or nil if there is none.
+	GetCheckpoint(ctx context.Context, id string) (*ReplicationCheckpoint, error)
+
+	// PutCheckpoint stores a checkpoint document and updates its Rev.
+	PutCheckpoint(ctx context.Context, checkpoint *ReplicationCheckpoint) error
+}
+
+// ReplicationSource is the endpoint documents are replicated from.
+type ReplicationSource interface {
+	ReplicationPeer
+
+	// Changes lists changes with all leaf revisions (style=all_docs).
+	Changes(ctx context.Context, options *ChangesOptions) (*ChangesResponse, error)
+
+	// GetRevisions returns the given revisions of a document, each including
+	// its _revisions history and the attachments added since attsSince.
+	GetRevisions(ctx context.Context, docID string, revs, attsSince []string) ([]map[string]any, error)
+}
+
+// ReplicationBulkSource is implemented by sources that can fetch the missing
+// revisions of many documents at once. RunReplication prefers it over
+// calling GetRevisions for each document.
+type ReplicationBulkSource interface {
+	ReplicationSource
+
+	// GetRevisionsBulk returns the missing revisions listed in a _revs_diff
+	// result, with the same contents as GetRevisions.
+	GetRevisionsBulk(ctx context.Context, missing map[string]RevsDiffResult) ([]map[string]any, error)
+}
+
+// ReplicationTarget is the endpoint documents are replicated to.
+type ReplicationTarget interface {
+	ReplicationPeer
+
+	// RevsDiff returns, per document ID, the given revisions the target lacks.
+	RevsDiff(ctx context.Context, revs map[string][]string) (map[string]RevsDiffResult, error)
+
+	// WriteRevisions stores documents with their existing revisions, without
+	// generating new ones (new_edits=false). Failed writes are reported in the
+	// response; successful writes may be omitted.
+	WriteRevisions(ctx context.Context, docs []map[string]any) (BulkDocsResponse, error)
+}
+
+// DatabasePeer is a CouchDB database acting as a replication source or target.
+type DatabasePeer struct {
+	client *Client
+	dbName string
+	opts   []RequestOption
+}
+
+// NewDatabasePeer creates a replication endpoint for a database reachable
+// through client. opts are applied to every request made on its behalf.
+func NewDatabasePeer(client *Client, dbName string, opts ...RequestOption) *DatabasePeer {
+	return &DatabasePeer{client: client, dbName: dbName, opts: opts}
+}
+
+// PeerID implements ReplicationPeer.
+func (p *DatabasePeer) PeerID() string {
+	return fmt.Sprintf("%s/%s", p.client.baseURL, p.dbName)
+}
+
+// GetCheckpoint implements ReplicationPeer.
+func (p *DatabasePeer) GetCheckpoint(ctx context.Context, id string) (*ReplicationCheckpoint, error) {
+	path := fmt.Sprintf("/%s/_local/%s", url.PathEscape(p.dbName), url.PathEscape(id))
+
+	resp, err := p.client.doRequest(ctx, operation{"DatabasePeer", "GetCheckpoint"}, http.MethodGet, path, nil, p.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get checkpoint: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var checkpoint ReplicationCheckpoint
+	if err := json.Unmarshal(body, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	}
+
+	return &checkpoint, nil
+}
+
+// PutCheckpoint implements ReplicationPeer.
+func (p *DatabasePeer) PutCheckpoint(ctx context.Context, checkpoint *ReplicationCheckpoint) error {
+	path := fmt.Sprintf("/%s/%s", url.PathEscape(p.dbName), checkpoint.ID)
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	resp, err := p.client.doRequest(ctx, operation{"DatabasePeer", "PutCheckpoint"}, http.MethodPut, path, bytes.NewReader(data), p.opts...)
+	if err != nil {
+		return fmt.Errorf("failed to put checkpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return fmt.Errorf("failed to put checkpoint: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var docResp DocumentResponse
+	if err := json.Unmarshal(body, &docResp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	checkpoint.Rev = docResp.Rev
+
+	return nil
+}
+
+// Changes implements ReplicationSource.
+func (p *DatabasePeer) Changes(ctx context.Context, options *ChangesOptions) (*ChangesResponse, error) {
+	return (&DatabaseService{client: p.client}).Changes(ctx, p.dbName, options, p.opts...)
+}
+
+// openRevsResult represents an entry in an open_revs response.
+type openRevsResult struct {
+	OK      map[string]any `json:"ok,omitempty"`
+	Missing string         `json:"missing,omitempty"`
+}
+
+// GetRevisions implements ReplicationSource using open_revs.
+func (p *DatabasePeer) GetRevisions(ctx context.Context, docID string, revs, attsSince []string) ([]map[string]any, error) {
+	path := fmt.Sprintf("/%s/%s", url.PathEscape(p.dbName), url.PathEscape(docID))
+
+	query := url.Values{}
+	openRevsJSON, err := json.Marshal(revs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal revisions: %w", err)
+	}
+	query.Set("open_revs", string(openRevsJSON))
+	query.Set("revs", "true")
+	query.Set("latest", "true")
+	query.Set("attachments", "true")
+	if len(attsSince) > 0 {
+		attsSinceJSON, err := json.Marshal(attsSince)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal revisions: %w", err)
+		}
+		query.Set("atts_since", string(attsSinceJSON))
+	}
+	path = fmt.Sprintf("%s?%s", path, query.Encode())
+
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+
+	resp, err := p.client.doRequestWithHeader(ctx, operation{"DatabasePeer", "GetRevisions"}, http.MethodGet, path, nil, header, p.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get revisions: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var results []openRevsResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal revisions: %w", err)
+	}
+
+	docs := make([]map[string]any, 0, len(results))
+	for _, result := range results {
+		if result.OK != nil {
+			docs = append(docs, result.OK)
+		}
+	}
+
+	return docs, nil
+}
+
+// GetRevisionsBulk implements ReplicationBulkSource using _bulk_get.
+// Revisions that no longer exist on the source are skipped.
+func (p *DatabasePeer) GetRevisionsBulk(ctx context.Context, missing map[string]RevsDiffResult) ([]map[string]any, error) {
+	var requested []BulkGetRequestDoc
+	for docID, diff := range missing {
+		for _, rev := range diff.Missing {
+			requested = append(requested, BulkGetRequestDoc{
+				ID:        docID,
+				Rev:       rev,
+				AttsSince: diff.PossibleAncestors,
+			})
+		}
+	}
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	options := &BulkGetOptions{Revs: true, Attachments: true, Latest: true}
+	bulkResp, err := (&DatabaseService{client: p.client}).BulkGet(ctx, p.dbName, requested, options, p.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []map[string]any
+	for _, result := range bulkResp.Results {
+		for _, doc := range result.Docs {
+			switch {
+			case doc.OK != nil:
+				docs = append(docs, doc.OK)
+			case doc.Error != nil && doc.Error.Err != "not_found":
+				return nil, doc.Error
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+// RevsDiff implements ReplicationTarget.
+func (p *DatabasePeer) RevsDiff(ctx context.Context, revs map[string][]string) (map[string]RevsDiffResult, error) {
+	return (&DatabaseService{client: p.client}).RevsDiff(ctx, p.dbName, revs, p.opts...)
+}
+
+// WriteRevisions implements ReplicationTarget.
+func (p *DatabasePeer) WriteRevisions(ctx context.Context, docs []map[string]any) (BulkDocsResponse, error) {
+	newEdits := false
+	return (&DatabaseService{client: p.client}).BulkDocs(ctx, p.dbName, docs, &BulkDocsOptions{NewEdits: &newEdits}, p.opts...)
+}
+
+// ClientReplicationOptions represents options for RunReplication.
+type ClientReplicationOptions struct {
+	// BatchSize is the number of changes processed per batch. Defaults to 100.
+	BatchSize int
+
+	// DocIDs, Selector and Filter restrict the replicated documents, with the
+	// same semantics as the corresponding _changes filters.
+	DocIDs      []string
+	Selector    map[string]any
+	Filter      string
+	QueryParams map[string]string
+
+	// Continuous keeps replicating new changes until ctx is done.
+	Continuous bool
+
+	// PollTimeout bounds each longpoll request of a continuous replication.
+	// Defaults to 60s.
+	PollTimeout time.Duration
+
+	// OnCheckpoint, if set, is called with the session statistics after every
+	// checkpoint.
+	OnCheckpoint func(ReplicationHistory)
+}
+
+// maxCheckpointHistory is the number of sessions kept in a checkpoint, as in
+// the CouchDB replicator.
+const maxCheckpointHistory = 50
+
+// RunReplication replicates documents from source to target, driven from the
+// client rather than by the server. It implements the CouchDB replication
+// protocol: changes are read from the source, the target is asked which
+// revisions it lacks via _revs_diff, missing revisions are fetched with their
+// history and written with new_edits=false, and progress is checkpointed in
+// _local documents on both ends, so interrupted replications resume where
+// they left off.
+//
+// RunReplication returns the statistics of the session. A continuous
+// replication runs until ctx is done and then returns ctx.Err().
+// Example usage:
+//
+//	source := NewDatabasePeer(client, "orders")
+//	target := NewDatabasePeer(backupClient, "orders")
+//	stats, err := RunReplication(ctx, source, target, nil)
+func RunReplication(ctx context.Context, source ReplicationSource, target ReplicationTarget, options *ClientReplicationOptions) (*ReplicationHistory, error) {
+	if options == nil {
+		options = &ClientReplicationOptions{}
+	}
+
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	pollTimeout := options.PollTimeout
+	if pollTimeout <= 0 {
+		pollTimeout = 60 * time.Second
+	}
+
+	replicationID, err := clientReplicationID(source, target, options)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceCheckpoint, err := source.GetCheckpoint(ctx, replicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source checkpoint: %w", err)
+	}
+	targetCheckpoint, err := target.GetCheckpoint(ctx, replicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read target checkpoint: %w", err)
+	}
+
+	since := startSeq(sourceCheckpoint, targetCheckpoint)
+
+	sessionID, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &ReplicationHistory{
+		SessionID:    sessionID,
+		StartTime:    time.Now().UTC().Format(time.RFC1123),
+		StartLastSeq: since,
+		EndLastSeq:   since,
+		RecordedSeq:  since,
+	}
+
+	for {
+		changesOptions := &ChangesOptions{
+			Since:       since,
+			Limit:       batchSize,
+			Style:       "all_docs",
+			DocIDs:      options.DocIDs,
+			Selector:    options.Selector,
+			Filter:      options.Filter,
+			QueryParams: options.QueryParams,
+		}
+		if options.Continuous {
+			changesOptions.Feed = "longpoll"
+			changesOptions.Timeout = int(pollTimeout / time.Millisecond)
+		}
+
+		changes, err := source.Changes(ctx, changesOptions)
+		if err != nil {
+			if ctx.Err() != nil {
+				return session, ctx.Err()
+			}
+			return session, fmt.Errorf("failed to read source changes: %w", err)
+		}
+
+		if len(changes.Results) > 0 {
+			if err := replicateBatch(ctx, source, target, changes.Results, session); err != nil {
+				return session, err
+			}
+		}
+
+		if changes.LastSeq != "" && changes.LastSeq != since {
+			since = changes.LastSeq
+			session.EndLastSeq = since
+			session.RecordedSeq = since
+			session.EndTime = time.Now().UTC().Format(time.RFC1123)
+
+			sourceCheckpoint = nextCheckpoint(sourceCheckpoint, replicationID, session)
+			targetCheckpoint = nextCheckpoint(targetCheckpoint, replicationID, session)
+			if err := target.PutCheckpoint(ctx, targetCheckpoint); err != nil {
+				return session, fmt.Errorf("failed to write target checkpoint: %w", err)
+			}
+			if err := source.PutCheckpoint(ctx, sourceCheckpoint); err != nil {
+				return session, fmt.Errorf("failed to write source checkpoint: %w", err)
+			}
+
+			if options.OnCheckpoint != nil {
+				options.OnCheckpoint(*session)
+			}
+		}
+
+		if !options.Continuous && len(changes.Results) < batchSize {
+			break
+		}
+
+		if err := ctx.Err(); err != nil {
+			return session, err
+		}
+	}
+
+	if session.EndTime == "" {
+		session.EndTime = time.Now().UTC().Format(time.RFC1123)
+	}
+
+	return session, nil
+}
+
+// replicateBatch copies the revisions listed in changes that target lacks.
+func replicateBatch(ctx context.Context, source ReplicationSource, target ReplicationTarget, changes []Change, session *ReplicationHistory) error {
+	revs := make(map[string][]string)
+	for _, change := range changes {
+		for _, rev := range change.Changes {
+			if !slices.Contains(revs[change.ID], rev.Rev) {
+				revs[change.ID] = append(revs[change.ID], rev.Rev)
+				session.MissingChecked++
+			}
+		}
+	}
+
+	diff, err := target.RevsDiff(ctx, revs)
+	if err != nil {
+		return fmt.Errorf("failed to diff target revisions: %w", err)
+	}
+
+	var docs []map[string]any
+	for _, missing := range diff {
+		session.MissingFound += len(missing.Missing)
+	}
+
+	if bulkSource, ok := source.(ReplicationBulkSource); ok {
+		docs, err = bulkSource.GetRevisionsBulk(ctx, diff)
+		if err != nil {
+			return fmt.Errorf("failed to fetch source revisions: %w", err)
+		}
+	} else {
+		docIDs := make([]string, 0, len(diff))
+		for docID := range diff {
+			docIDs = append(docIDs, docID)
+		}
+		slices.Sort(docIDs)
+
+		for _, docID := range docIDs {
+			missing := diff[docID]
+			if len(missing.Missing) == 0 {
+				continue
+			}
+
+			fetched, err := source.GetRevisions(ctx, docID, missing.Missing, missing.PossibleAncestors)
+			if err != nil {
+				return fmt.Errorf("failed to fetch source revisions of %s: %w", docID, err)
+			}
+			docs = append(docs, fetched...)
+		}
+	}
+	session.DocsRead += len(docs)
+
+	if len(docs) == 0 {
+		return nil
+	}
+
+	results, err := target.WriteRevisions(ctx, docs)
+	if err != nil {
+		return fmt.Errorf("failed to write target revisions: %w", err)
+	}
+
+	failures := 0
+	for _, result := range results {
+		if result.Err() != nil {
+			failures++
+		}
+	}
+	session.DocWriteFailures += failures
+	session.DocsWritten += len(docs) - failures
+
+	return nil
+}
+
+// clientReplicationID derives the checkpoint document ID from the endpoints
+// and the filter options.
+func clientReplicationID(source ReplicationSource, target ReplicationTarget, options *ClientReplicationOptions) (string, error) {
+	filter, err := json.Marshal(map[string]any{
+		"doc_ids":      options.DocIDs,
+		"selector":     options.Selector,
+		"filter":       options.Filter,
+		"query_params": options.QueryParams,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal replication filter: %w", err)
+	}
+
+	sum := md5.Sum([]byte(source.PeerID() + "\n" + target.PeerID() + "\n" + string(filter)))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// startSeq finds the sequence to resume from. Both checkpoints must agree on
+// a session; otherwise the replication starts from scratch.
+func startSeq(source, target *ReplicationCheckpoint) string {
+	if source == nil || target == nil {
+		return ""
+	}
+
+	if source.SessionID == target.SessionID {
+		return seqString(source.SourceLastSeq)
+	}
+
+	for _, sourceEntry := range source.History {
+		for _, targetEntry := range target.History {
+			if sourceEntry.SessionID == targetEntry.SessionID {
+				return seqString(sourceEntry.RecordedSeq)
+			}
+		}
+	}
+
+	return ""
+}
+
+// nextCheckpoint returns checkpoint updated with the current session.
+func nextCheckpoint(checkpoint *ReplicationCheckpoint, replicationID string, session *ReplicationHistory) *ReplicationCheckpoint {
+	next := &ReplicationCheckpoint{
+		ID:                   "_local/" + replicationID,
+		SessionID:            session.SessionID,
+		SourceLastSeq:        session.RecordedSeq,
+		ReplicationIDVersion: 4,
+	}
+
+	var history []ReplicationHistory
+	if checkpoint != nil {
+		next.Rev = checkpoint.Rev
+		history = checkpoint.History
+		if len(history) > 0 && history[0].SessionID == session.SessionID {
+			history = history[1:]
+		}
+	}
+
+	next.History = append([]ReplicationHistory{*session}, history...)
+	if len(next.History) > maxCheckpointHistory {
+		next.History = next.History[:maxCheckpointHistory]
+	}
+
+	return next
+}
+
+func seqString(seq any) string {
+	switch v := seq.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(seq)
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,201 @@
+package couchdb
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestStartSeq(t *testing.T) {
+	history := func(pairs ...any) []ReplicationHistory {
+		var h []ReplicationHistory
+		for i := 0; i < len(pairs); i += 2 {
+			h = append(h, ReplicationHistory{SessionID: pairs[i].(string), RecordedSeq: pairs[i+1]})
+		}
+		return h
+	}
+
+	tests := []struct {
+		name   string
+		source *ReplicationCheckpoint
+		target *ReplicationCheckpoint
+		want   string
+	}{
+		{
+			name: "no checkpoints",
+		},
+		{
+			name:   "source only",
+			source: &ReplicationCheckpoint{SessionID: "s1", SourceLastSeq: "10-abc"},
+		},
+		{
+			name:   "target only",
+			target: &ReplicationCheckpoint{SessionID: "s1", SourceLastSeq: "10-abc"},
+		},
+		{
+			name:   "same session",
+			source: &ReplicationCheckpoint{SessionID: "s2", SourceLastSeq: "20-def", History: history("s2", "20-def", "s1", "10-abc")},
+			target: &ReplicationCheckpoint{SessionID: "s2", SourceLastSeq: "20-def", History: history("s2", "20-def", "s1", "10-abc")},
+			want:   "20-def",
+		},
+		{
+			name:   "numeric sequence",
+			source: &ReplicationCheckpoint{SessionID: "s1", SourceLastSeq: float64(42)},
+			target: &ReplicationCheckpoint{SessionID: "s1", SourceLastSeq: float64(42)},
+			want:   "42",
+		},
+		{
+			name:   "target behind",
+			source: &ReplicationCheckpoint{SessionID: "s3", SourceLastSeq: "30-ghi", History: history("s3", "30-ghi", "s2", "20-def", "s1", "10-abc")},
+			target: &ReplicationCheckpoint{SessionID: "s2", SourceLastSeq: "20-def", History: history("s2", "20-def", "s1", "10-abc")},
+			want:   "20-def",
+		},
+		{
+			name:   "source behind",
+			source: &ReplicationCheckpoint{SessionID: "s1", SourceLastSeq: "10-abc", History: history("s1", "10-abc")},
+			target: &ReplicationCheckpoint{SessionID: "s2", SourceLastSeq: "20-def", History: history("s2", "20-def", "s1", "10-abc")},
+			want:   "10-abc",
+		},
+		{
+			name:   "no common session",
+			source: &ReplicationCheckpoint{SessionID: "s1", SourceLastSeq: "10-abc", History: history("s1", "10-abc")},
+			target: &ReplicationCheckpoint{SessionID: "s9", SourceLastSeq: "90-xyz", History: history("s9", "90-xyz")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := startSeq(tt.source, tt.target); got != tt.want {
+				t.Errorf("startSeq() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextCheckpoint(t *testing.T) {
+	session := &ReplicationHistory{SessionID: "s2", RecordedSeq: "20-def", DocsWritten: 5}
+
+	long := make([]ReplicationHistory, maxCheckpointHistory)
+	for i := range long {
+		long[i] = ReplicationHistory{SessionID: fmt.Sprintf("old%d", i)}
+	}
+
+	tests := []struct {
+		name        string
+		checkpoint  *ReplicationCheckpoint
+		wantRev     string
+		wantHistory []string
+	}{
+		{
+			name:        "first checkpoint",
+			wantHistory: []string{"s2"},
+		},
+		{
+			name: "new session",
+			checkpoint: &ReplicationCheckpoint{
+				Rev:     "1-a",
+				History: []ReplicationHistory{{SessionID: "s1"}},
+			},
+			wantRev:     "1-a",
+			wantHistory: []string{"s2", "s1"},
+		},
+		{
+			name: "same session replaced",
+			checkpoint: &ReplicationCheckpoint{
+				Rev:     "2-b",
+				History: []ReplicationHistory{{SessionID: "s2", RecordedSeq: "15-x"}, {SessionID: "s1"}},
+			},
+			wantRev:     "2-b",
+			wantHistory: []string{"s2", "s1"},
+		},
+		{
+			name:       "history trimmed",
+			checkpoint: &ReplicationCheckpoint{Rev: "3-c", History: long},
+			wantRev:    "3-c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextCheckpoint(tt.checkpoint, "abc", session)
+
+			if got.ID != "_local/abc" || got.Rev != tt.wantRev || got.SessionID != "s2" || got.SourceLastSeq != "20-def" {
+				t.Errorf("nextCheckpoint() = %+v", got)
+			}
+			if !reflect.DeepEqual(got.History[0], *session) {
+				t.Errorf("History[0] = %+v, want %+v", got.History[0], *session)
+			}
+			if len(got.History) > maxCheckpointHistory {
+				t.Errorf("len(History) = %d, want at most %d", len(got.History), maxCheckpointHistory)
+			}
+			if tt.wantHistory != nil {
+				var ids []string
+				for _, h := range got.History {
+					ids = append(ids, h.SessionID)
+				}
+				if !reflect.DeepEqual(ids, tt.wantHistory) {
+					t.Errorf("History sessions = %v, want %v", ids, tt.wantHistory)
+				}
+			}
+		})
+	}
+}
+
+func TestSeqString(t *testing.T) {
+	tests := []struct {
+		seq  any
+		want string
+	}{
+		{nil, ""},
+		{"12-g1AAAA", "12-g1AAAA"},
+		{float64(42), "42"},
+		{float64(1e21), "1000000000000000000000"},
+		{[]any{float64(1), "x"}, "[1 x]"},
+	}
+
+	for _, tt := range tests {
+		if got := seqString(tt.seq); got != tt.want {
+			t.Errorf("seqString(%v) = %q, want %q", tt.seq, got, tt.want)
+		}
+	}
+}
+
+func TestClientReplicationID(t *testing.T) {
+	client := NewClient("http://localhost:5984")
+	source := NewDatabasePeer(client, "a")
+	target := NewDatabasePeer(client, "b")
+
+	id := func(source, target *DatabasePeer, options *ClientReplicationOptions) string {
+		t.Helper()
+		id, err := clientReplicationID(source, target, options)
+		if err != nil {
+			t.Fatalf("clientReplicationID() error = %v", err)
+		}
+		return id
+	}
+
+	base := id(source, target, &ClientReplicationOptions{})
+
+	tests := []struct {
+		name    string
+		source  *DatabasePeer
+		target  *DatabasePeer
+		options *ClientReplicationOptions
+		same    bool
+	}{
+		{"same endpoints", source, target, &ClientReplicationOptions{BatchSize: 10, Continuous: true}, true},
+		{"swapped endpoints", target, source, &ClientReplicationOptions{}, false},
+		{"doc ids", source, target, &ClientReplicationOptions{DocIDs: []string{"x"}}, false},
+		{"selector", source, target, &ClientReplicationOptions{Selector: map[string]any{"type": "x"}}, false},
+		{"filter", source, target, &ClientReplicationOptions{Filter: "ddoc/f"}, false},
+		{"query params", source, target, &ClientReplicationOptions{Filter: "ddoc/f", QueryParams: map[string]string{"a": "1"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := id(tt.source, tt.target, tt.options); (got == base) != tt.same {
+				t.Errorf("clientReplicationID() = %s, base %s, want same = %t", got, base, tt.same)
+			}
+		})
+	}
+}
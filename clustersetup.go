@@ -0,0 +1,302 @@
+package couchdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// Cluster setup states reported by GetState.
+const (
+	ClusterSetupClusterDisabled    = "cluster_disabled"
+	ClusterSetupSingleNodeDisabled = "single_node_disabled"
+	ClusterSetupSingleNodeEnabled  = "single_node_enabled"
+	ClusterSetupClusterEnabled     = "cluster_enabled"
+	ClusterSetupClusterFinished    = "cluster_finished"
+)
+
+// ClusterSetupService provides methods for setting up a node as a cluster
+// member or a single node.
+// See: https://docs.couchdb.org/en/stable/setup/cluster.html
+type ClusterSetupService struct {
+	client *Client
+}
+
+// NewClusterSetupService creates a new ClusterSetupService.
+func NewClusterSetupService(client *Client) *ClusterSetupService {
+	return &ClusterSetupService{client: client}
+}
+
+// ClusterSetupStatus represents the setup state of a node.
+type ClusterSetupStatus struct {
+	State string `json:"state"`
+}
+
+// EnableClusterRequest represents the enable_cluster action.
+type EnableClusterRequest struct {
+	BindAddress string `json:"bind_address,omitempty"`
+	Port        int    `json:"port,omitempty"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	NodeCount   int    `json:"node_count,omitempty"`
+
+	// RemoteNode, if set, is the node to enable, with the credentials of an
+	// existing admin on it.
+	RemoteNode            string `json:"remote_node,omitempty"`
+	RemoteCurrentUser     string `json:"remote_current_user,omitempty"`
+	RemoteCurrentPassword string `json:"remote_current_password,omitempty"`
+}
+
+// AddNodeRequest represents the add_node action.
+type AddNodeRequest struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port,omitempty"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Name     string `json:"name,omitempty"` // Erlang node name, if not couchdb@{host}
+}
+
+// FinishClusterRequest represents the finish_cluster action.
+type FinishClusterRequest struct {
+	// EnsureDBsExist lists the system databases to create. Defaults to
+	// _users, _replicator and _global_changes.
+	EnsureDBsExist []string `json:"ensure_dbs_exist,omitempty"`
+}
+
+// EnableSingleNodeRequest represents the enable_single_node action.
+type EnableSingleNodeRequest struct {
+	BindAddress string `json:"bind_address,omitempty"`
+	Port        int    `json:"port,omitempty"`
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+}
+
+// GetState returns the setup state of the node. ensureDBsExist lists the
+// system databases that must exist for the setup to count as finished; nil
+// checks the default ones.
+// GET /_cluster_setup
+func (s *ClusterSetupService) GetState(ctx context.Context, ensureDBsExist []string, opts ...RequestOption) (*ClusterSetupStatus, error) {
+	path := "/_cluster_setup"
+	if len(ensureDBsExist) > 0 {
+		dbsJSON, err := json.Marshal(ensureDBsExist)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal databases: %w", err)
+		}
+		path = fmt.Sprintf("%s?ensure_dbs_exist=%s", path, url.QueryEscape(string(dbsJSON)))
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ClusterSetupService", "GetState"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster setup state: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get cluster setup state: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var status ClusterSetupStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cluster setup state: %w", err)
+	}
+
+	return &status, nil
+}
+
+// WaitForState polls GetState every interval until the node reaches one of
+// the given states. Errors are retried, since nodes are often polled while
+// they are still starting up; the last one is returned if ctx is done first.
+// A non-positive interval defaults to 1s.
+// Example usage:
+//
+//	status, err := setup.WaitForState(ctx, nil, time.Second, []string{
+//		ClusterSetupClusterFinished,
+//		ClusterSetupSingleNodeEnabled,
+//	}, WithBasicAuth("admin", "password"))
+func (s *ClusterSetupService) WaitForState(ctx context.Context, ensureDBsExist []string, interval time.Duration, states []string, opts ...RequestOption) (*ClusterSetupStatus, error) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		status, err := s.GetState(ctx, ensureDBsExist, opts...)
+		if err == nil {
+			if slices.Contains(states, status.State) {
+				return status, nil
+			}
+			lastErr = fmt.Errorf("cluster setup state is %s", status.State)
+		} else {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ctx.Err(), lastErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// EnableCluster prepares a node to join a cluster, setting its admin
+// credentials and bind address. With RemoteNode set, the request is forwarded
+// to that node instead.
+// POST /_cluster_setup
+func (s *ClusterSetupService) EnableCluster(ctx context.Context, req *EnableClusterRequest, opts ...RequestOption) error {
+	body := struct {
+		Action string `json:"action"`
+		*EnableClusterRequest
+	}{Action: "enable_cluster", EnableClusterRequest: req}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cluster setup request: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ClusterSetupService", "EnableCluster"}, http.MethodPost, "/_cluster_setup", bytes.NewReader(data), opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enable cluster: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+		}
+		return fmt.Errorf("failed to enable cluster: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	return nil
+}
+
+// AddNode adds a node, prepared with EnableCluster, to the cluster of the
+// node handling the request.
+// POST /_cluster_setup
+func (s *ClusterSetupService) AddNode(ctx context.Context, req *AddNodeRequest, opts ...RequestOption) error {
+	body := struct {
+		Action string `json:"action"`
+		*AddNodeRequest
+	}{Action: "add_node", AddNodeRequest: req}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cluster setup request: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ClusterSetupService", "AddNode"}, http.MethodPost, "/_cluster_setup", bytes.NewReader(data), opts...)
+	if err != nil {
+		return fmt.Errorf("failed to add node: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+		}
+		return fmt.Errorf("failed to add node: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	return nil
+}
+
+// FinishCluster completes the setup of a cluster by creating the system
+// databases once all nodes have been added.
+// POST /_cluster_setup
+func (s *ClusterSetupService) FinishCluster(ctx context.Context, req *FinishClusterRequest, opts ...RequestOption) error {
+	body := struct {
+		Action string `json:"action"`
+		*FinishClusterRequest
+	}{Action: "finish_cluster", FinishClusterRequest: req}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cluster setup request: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ClusterSetupService", "FinishCluster"}, http.MethodPost, "/_cluster_setup", bytes.NewReader(data), opts...)
+	if err != nil {
+		return fmt.Errorf("failed to finish cluster: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+		}
+		return fmt.Errorf("failed to finish cluster: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	return nil
+}
+
+// EnableSingleNode configures the node as a standalone server and creates
+// the system databases.
+// POST /_cluster_setup
+func (s *ClusterSetupService) EnableSingleNode(ctx context.Context, req *EnableSingleNodeRequest, opts ...RequestOption) error {
+	body := struct {
+		Action string `json:"action"`
+		*EnableSingleNodeRequest
+	}{Action: "enable_single_node", EnableSingleNodeRequest: req}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cluster setup request: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ClusterSetupService", "EnableSingleNode"}, http.MethodPost, "/_cluster_setup", bytes.NewReader(data), opts...)
+	if err != nil {
+		return fmt.Errorf("failed to enable single node: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+		}
+		return fmt.Errorf("failed to enable single node: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	return nil
+}
@@ -0,0 +1,333 @@
+package couchdb
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Fragmentation returns the share of the file that is not used by live data,
+// between 0 and 1.
+func (s DatabaseInfoSizes) Fragmentation() float64 {
+	if s.File <= 0 || s.Active >= s.File {
+		return 0
+	}
+	return float64(s.File-s.Active) / float64(s.File)
+}
+
+// CompactionAdvisorOptions configures a CompactionAdvisor.
+type CompactionAdvisorOptions struct {
+	// DatabaseThreshold is the fragmentation above which a database is
+	// compacted. Defaults to 0.5.
+	DatabaseThreshold float64
+
+	// ViewThreshold is the fragmentation above which the view index of a
+	// design document is compacted. Defaults to 0.5.
+	ViewThreshold float64
+
+	// MinFileSize is the file size in bytes below which nothing is compacted,
+	// however fragmented. Defaults to 1 MiB.
+	MinFileSize int
+
+	// Databases restricts the scan to the given databases. Defaults to all
+	// databases on the server.
+	Databases []string
+
+	// SkipViews disables scanning of design document view indexes.
+	SkipViews bool
+
+	// MaxConcurrent is the number of compactions run at once. Defaults to 1.
+	MaxConcurrent int
+
+	// PollInterval is how often a running compaction is checked for
+	// completion. Defaults to 5s.
+	PollInterval time.Duration
+
+	// DryRun makes Run report what it would compact without compacting.
+	DryRun bool
+}
+
+// CompactionAdvice describes a database or view index examined by a
+// CompactionAdvisor.
+type CompactionAdvice struct {
+	Database string
+	// DesignDoc is set for view indexes and empty for databases.
+	DesignDoc     string
+	Sizes         DatabaseInfoSizes
+	Fragmentation float64
+
+	// Running is set if a compaction was already in progress.
+	Running bool
+	// Scheduled is set if the fragmentation exceeds the threshold.
+	Scheduled bool
+
+	// Result is set once a scheduled compaction has completed.
+	Result *CompactionResult
+	Err    error
+}
+
+// CompactionReport lists the databases and view indexes examined by a
+// CompactionAdvisor, the most wasteful first.
+type CompactionReport struct {
+	DryRun bool
+	Advice []CompactionAdvice
+}
+
+// Scheduled returns the entries that exceed their fragmentation threshold.
+func (r *CompactionReport) Scheduled() []CompactionAdvice {
+	var scheduled []CompactionAdvice
+	for _, advice := range r.Advice {
+		if advice.Scheduled {
+			scheduled = append(scheduled, advice)
+		}
+	}
+	return scheduled
+}
+
+// String formats the report as a table.
+func (r *CompactionReport) String() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "DATABASE\tDESIGN DOC\tFILE\tACTIVE\tFRAGMENTATION\tACTION")
+	for _, advice := range r.Advice {
+		action := "-"
+		switch {
+		case advice.Err != nil:
+			action = "error: " + advice.Err.Error()
+		case advice.Running:
+			action = "already running"
+		case advice.Result != nil:
+			action = fmt.Sprintf("compacted, saved %d bytes", advice.Result.Saved())
+		case advice.Scheduled && r.DryRun:
+			action = "would compact"
+		case advice.Scheduled:
+			action = "compact"
+		}
+
+		ddoc := advice.DesignDoc
+		if ddoc == "" {
+			ddoc = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%.1f%%\t%s\n",
+			advice.Database, ddoc, advice.Sizes.File, advice.Sizes.Active,
+			advice.Fragmentation*100, action)
+	}
+
+	w.Flush()
+	return b.String()
+}
+
+// CompactionAdvisor finds fragmented databases and view indexes and compacts
+// them.
+// Example usage:
+//
+//	advisor := NewCompactionAdvisor(client, &CompactionAdvisorOptions{
+//		DatabaseThreshold: 0.3,
+//		MaxConcurrent:     2,
+//		DryRun:            true,
+//	}, WithBasicAuth("admin", "password"))
+//	report, err := advisor.Run(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Print(report)
+type CompactionAdvisor struct {
+	client  *Client
+	options CompactionAdvisorOptions
+	opts    []RequestOption
+}
+
+// NewCompactionAdvisor creates a CompactionAdvisor. opts are applied to every
+// request made on its behalf and usually carry admin credentials.
+func NewCompactionAdvisor(client *Client, options *CompactionAdvisorOptions, opts ...RequestOption) *CompactionAdvisor {
+	var o CompactionAdvisorOptions
+	if options != nil {
+		o = *options
+	}
+	if o.DatabaseThreshold <= 0 {
+		o.DatabaseThreshold = 0.5
+	}
+	if o.ViewThreshold <= 0 {
+		o.ViewThreshold = 0.5
+	}
+	if o.MinFileSize <= 0 {
+		o.MinFileSize = 1 << 20
+	}
+	if o.MaxConcurrent <= 0 {
+		o.MaxConcurrent = 1
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = 5 * time.Second
+	}
+
+	return &CompactionAdvisor{client: client, options: o, opts: opts}
+}
+
+// Scan examines the databases and view indexes without compacting anything.
+// Databases that cannot be examined are reported with Err set.
+func (a *CompactionAdvisor) Scan(ctx context.Context) (*CompactionReport, error) {
+	dbNames := a.options.Databases
+	if dbNames == nil {
+		var err error
+		dbNames, err = a.client.Server().AllDbs(ctx, nil, a.opts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report := &CompactionReport{DryRun: true}
+	for _, dbName := range dbNames {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		report.Advice = append(report.Advice, a.scanDatabase(ctx, dbName)...)
+	}
+
+	slices.SortStableFunc(report.Advice, func(x, y CompactionAdvice) int {
+		return cmp.Compare(y.Sizes.File-y.Sizes.Active, x.Sizes.File-x.Sizes.Active)
+	})
+
+	return report, nil
+}
+
+// scanDatabase examines a database and the view indexes of its design
+// documents.
+func (a *CompactionAdvisor) scanDatabase(ctx context.Context, dbName string) []CompactionAdvice {
+	info, err := a.client.Databases().GetDatabase(ctx, dbName, a.opts...)
+	if err != nil {
+		return []CompactionAdvice{{Database: dbName, Err: err}}
+	}
+
+	advice := []CompactionAdvice{
+		a.advise(dbName, "", info.Sizes, info.CompactRunning, a.options.DatabaseThreshold),
+	}
+	if a.options.SkipViews {
+		return advice
+	}
+
+	ddocs, err := a.client.Databases().AllDocs(ctx, dbName, &AllDocsOptions{
+		StartKey: "_design/",
+		EndKey:   "_design0",
+	}, a.opts...)
+	if err != nil {
+		advice[0].Err = err
+		return advice
+	}
+
+	for _, row := range ddocs.Rows {
+		ddoc := strings.TrimPrefix(row.ID, "_design/")
+
+		ddocInfo, err := a.client.DesignDocuments().GetDesignDocumentInfo(ctx, dbName, ddoc, a.opts...)
+		if err != nil {
+			advice = append(advice, CompactionAdvice{Database: dbName, DesignDoc: ddoc, Err: err})
+			continue
+		}
+
+		index := ddocInfo.ViewIndex
+		advice = append(advice, a.advise(dbName, ddoc, index.Sizes, index.CompactRunning, a.options.ViewThreshold))
+	}
+
+	return advice
+}
+
+func (a *CompactionAdvisor) advise(dbName, ddoc string, sizes DatabaseInfoSizes, running bool, threshold float64) CompactionAdvice {
+	fragmentation := sizes.Fragmentation()
+	return CompactionAdvice{
+		Database:      dbName,
+		DesignDoc:     ddoc,
+		Sizes:         sizes,
+		Fragmentation: fragmentation,
+		Running:       running,
+		Scheduled:     !running && sizes.File >= a.options.MinFileSize && fragmentation > threshold,
+	}
+}
+
+// Run scans the databases and view indexes and compacts those whose
+// fragmentation exceeds the thresholds, the most wasteful first, running at
+// most MaxConcurrent compactions at once. Each compaction is waited for
+// before the next one starts in its slot. In dry-run mode Run only scans.
+//
+// Failed compactions are reported with Err set. If ctx is done, no further
+// compactions are started and Run returns the report with ctx.Err().
+func (a *CompactionAdvisor) Run(ctx context.Context) (*CompactionReport, error) {
+	report, err := a.Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report.DryRun = a.options.DryRun
+	if report.DryRun {
+		return report, nil
+	}
+
+	slots := make(chan struct{}, a.options.MaxConcurrent)
+	var wg sync.WaitGroup
+
+	for i := range report.Advice {
+		if !report.Advice[i].Scheduled {
+			continue
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(advice *CompactionAdvice) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			advice.Result, advice.Err = a.compact(ctx, advice)
+		}(&report.Advice[i])
+	}
+
+	wg.Wait()
+	return report, ctx.Err()
+}
+
+// compact compacts a database or view index and waits for it to complete.
+func (a *CompactionAdvisor) compact(ctx context.Context, advice *CompactionAdvice) (*CompactionResult, error) {
+	if advice.DesignDoc == "" {
+		return a.client.Databases().CompactAndWait(ctx, advice.Database, a.options.PollInterval, a.opts...)
+	}
+
+	start := time.Now()
+	if _, err := a.client.Databases().CompactViews(ctx, advice.Database, advice.DesignDoc, a.opts...); err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(a.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		info, err := a.client.DesignDocuments().GetDesignDocumentInfo(ctx, advice.Database, advice.DesignDoc, a.opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.ViewIndex.CompactRunning {
+			return &CompactionResult{
+				Before:   advice.Sizes,
+				After:    info.ViewIndex.Sizes,
+				Duration: time.Since(start),
+			}, nil
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package couchdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// ConfigChange describes a configuration value that differs from the desired
+// state on a node.
+type ConfigChange struct {
+	Node    string
+	Section string
+	Key     string
+	// Old is the current value; Exists is false if the key is not set.
+	Old    string
+	Exists bool
+	New    string
+	// Delete is set if the key is to be removed; New is then ignored.
+	Delete bool
+}
+
+// String formats the change as "node [section] key: old -> new".
+func (c ConfigChange) String() string {
+	old, value := "(unset)", "(unset)"
+	if c.Exists {
+		old = fmt.Sprintf("%q", c.Old)
+	}
+	if !c.Delete {
+		value = fmt.Sprintf("%q", c.New)
+	}
+	return fmt.Sprintf("%s [%s] %s: %s -> %s", c.Node, c.Section, c.Key, old, value)
+}
+
+// ConfigApplyOptions configures ConfigurationService.Apply.
+type ConfigApplyOptions struct {
+	// Nodes restricts Apply to the given nodes. Defaults to the cluster nodes
+	// reported by _membership.
+	Nodes []string
+
+	// DryRun computes the changes without making them.
+	DryRun bool
+}
+
+// ConfigApplyResult reports the outcome of ConfigurationService.Apply.
+type ConfigApplyResult struct {
+	// Changes lists the differences found, grouped by node and
+	// ordered by section and key.
+	Changes []ConfigChange
+	// Applied is the number of changes in effect on the nodes. It is 0 in
+	// dry-run mode and after a complete rollback; if some reverts failed, it
+	// counts the changes that are still in effect.
+	Applied int
+	// RolledBack is set if a change failed and all the changes made before
+	// it were reverted.
+	RolledBack bool
+}
+
+// Apply brings the configuration of every node to the desired state. desired
+// maps sections to keys to values; keys that are not listed are left alone.
+// Only values that differ are written.
+//
+// The configuration of every node is read before anything is written. If a
+// write fails, the changes already made are reverted in reverse order, and
+// the error reports both the failure and any failed reverts.
+//
+// Values in the admins section are hashed by the server and therefore always
+// differ; manage them with CreateAdmin and UpdateAdminPassword instead.
+// Example usage:
+//
+//	result, err := configService.Apply(ctx, map[string]map[string]string{
+//		"chttpd":      {"max_http_request_size": "4294967296"},
+//		"couchdb":     {"max_document_size": "8000000"},
+//		"chttpd_auth": {"timeout": "3600"},
+//	}, &ConfigApplyOptions{DryRun: true}, WithBasicAuth("admin", "password"))
+//	if err != nil {
+//		return err
+//	}
+//	for _, change := range result.Changes {
+//		fmt.Println(change)
+//	}
+func (s *ConfigurationService) Apply(ctx context.Context, desired map[string]map[string]string, options *ConfigApplyOptions, opts ...RequestOption) (*ConfigApplyResult, error) {
+	var o ConfigApplyOptions
+	if options != nil {
+		o = *options
+	}
+
+	nodes := o.Nodes
+	if len(nodes) == 0 {
+		membership, err := s.client.Server().GetMembership(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		nodes = membership.ClusterNodes
+	}
+
+	result := &ConfigApplyResult{}
+	for _, node := range nodes {
+		current, err := s.GetConfiguration(ctx, node, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("node %s: %w", node, err)
+		}
+		result.Changes = append(result.Changes, configDiff(node, current, desired)...)
+	}
+
+	if o.DryRun {
+		return result, nil
+	}
+
+	return result, s.applyChanges(ctx, result, opts...)
+}
+
+// applyChanges makes the changes of result in order, reverting the changes
+// already made if one fails.
+func (s *ConfigurationService) applyChanges(ctx context.Context, result *ConfigApplyResult, opts ...RequestOption) error {
+	for i, change := range result.Changes {
+		var err error
+		if change.Delete {
+			_, err = s.DeleteConfigurationValue(ctx, change.Node, change.Section, change.Key, opts...)
+		} else {
+			_, err = s.SetConfigurationValue(ctx, change.Node, change.Section, change.Key, change.New, opts...)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to apply %s: %w", change, err)
+
+			// Revert even if ctx is done; the rollback must not be cut short.
+			reverted, rollbackErr := s.revert(context.WithoutCancel(ctx), result.Changes[:i], opts...)
+			result.Applied = i - reverted
+			result.RolledBack = rollbackErr == nil
+			return errors.Join(err, rollbackErr)
+		}
+		result.Applied = i + 1
+	}
+
+	return nil
+}
+
+// revert undoes changes in reverse order and returns how many were undone.
+// It carries on past failed reverts.
+func (s *ConfigurationService) revert(ctx context.Context, changes []ConfigChange, opts ...RequestOption) (int, error) {
+	reverted := 0
+	var errs []error
+	for _, change := range slices.Backward(changes) {
+		var err error
+		if change.Exists {
+			_, err = s.SetConfigurationValue(ctx, change.Node, change.Section, change.Key, change.Old, opts...)
+		} else {
+			_, err = s.DeleteConfigurationValue(ctx, change.Node, change.Section, change.Key, opts...)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to revert %s: %w", change, err))
+			continue
+		}
+		reverted++
+	}
+	return reverted, errors.Join(errs...)
+}
+
+// configDiff returns the desired values that differ from current, ordered by
+// section and key.
+func configDiff(node string, current, desired map[string]map[string]string) []ConfigChange {
+	var changes []ConfigChange
+
+	for _, section := range slices.Sorted(maps.Keys(desired)) {
+		for _, key := range slices.Sorted(maps.Keys(desired[section])) {
+			value := desired[section][key]
+			old, exists := current[section][key]
+			if exists && old == value {
+				continue
+			}
+			changes = append(changes, ConfigChange{
+				Node:    node,
+				Section: section,
+				Key:     key,
+				Old:     old,
+				Exists:  exists,
+				New:     value,
+			})
+		}
+	}
+
+	return changes
+}
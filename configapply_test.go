@@ -0,0 +1,128 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigDiff(t *testing.T) {
+	current := map[string]map[string]string{
+		"chttpd":  {"port": "5984", "bind_address": "0.0.0.0"},
+		"couchdb": {"max_document_size": "8000000"},
+	}
+
+	tests := []struct {
+		name    string
+		desired map[string]map[string]string
+		want    []ConfigChange
+	}{
+		{
+			name:    "unchanged",
+			desired: map[string]map[string]string{"chttpd": {"port": "5984"}},
+		},
+		{
+			name:    "changed",
+			desired: map[string]map[string]string{"chttpd": {"port": "6984"}},
+			want: []ConfigChange{
+				{Node: "n1", Section: "chttpd", Key: "port", Old: "5984", Exists: true, New: "6984"},
+			},
+		},
+		{
+			name:    "new key and section",
+			desired: map[string]map[string]string{"log": {"level": "debug"}, "chttpd": {"max_connections": "2048"}},
+			want: []ConfigChange{
+				{Node: "n1", Section: "chttpd", Key: "max_connections", New: "2048"},
+				{Node: "n1", Section: "log", Key: "level", New: "debug"},
+			},
+		},
+		{
+			name:    "empty value differs from unset",
+			desired: map[string]map[string]string{"couchdb": {"uuid": ""}},
+			want: []ConfigChange{
+				{Node: "n1", Section: "couchdb", Key: "uuid", New: ""},
+			},
+		},
+		{
+			name: "sorted by section and key",
+			desired: map[string]map[string]string{
+				"couchdb": {"max_document_size": "1", "attachment_stream_buffer_size": "4096"},
+				"chttpd":  {"port": "1"},
+			},
+			want: []ConfigChange{
+				{Node: "n1", Section: "chttpd", Key: "port", Old: "5984", Exists: true, New: "1"},
+				{Node: "n1", Section: "couchdb", Key: "attachment_stream_buffer_size", New: "4096"},
+				{Node: "n1", Section: "couchdb", Key: "max_document_size", Old: "8000000", Exists: true, New: "1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := configDiff("n1", current, tt.desired); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("configDiff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyChangesRollback(t *testing.T) {
+	changes := []ConfigChange{
+		{Node: "n1", Section: "a", Key: "k1", Old: "1", Exists: true, New: "2"},
+		{Node: "n1", Section: "a", Key: "k2", New: "2"},
+		{Node: "n1", Section: "a", Key: "k3", New: "2"},
+	}
+
+	tests := []struct {
+		name           string
+		fail           map[string]bool // "METHOD key" pairs that fail
+		wantApplied    int
+		wantRolledBack bool
+	}{
+		{
+			name:        "success",
+			wantApplied: 3,
+		},
+		{
+			name:           "rolled back",
+			fail:           map[string]bool{"PUT k3": true},
+			wantApplied:    0,
+			wantRolledBack: true,
+		},
+		{
+			name:        "revert fails",
+			fail:        map[string]bool{"PUT k3": true, "DELETE k2": true},
+			wantApplied: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				key := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+				if tt.fail[r.Method+" "+key] {
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte(`{"error":"unknown_error","reason":"boom"}`))
+					return
+				}
+				w.Write([]byte(`""`))
+			}))
+			defer server.Close()
+
+			s := NewConfigurationService(NewClient(server.URL))
+			result := &ConfigApplyResult{Changes: changes}
+			err := s.applyChanges(context.Background(), result)
+
+			if (err != nil) != (tt.fail != nil) {
+				t.Fatalf("applyChanges() error = %v", err)
+			}
+			if result.Applied != tt.wantApplied || result.RolledBack != tt.wantRolledBack {
+				t.Errorf("Applied = %d, RolledBack = %t, want %d, %t",
+					result.Applied, result.RolledBack, tt.wantApplied, tt.wantRolledBack)
+			}
+		})
+	}
+}
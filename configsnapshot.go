@@ -0,0 +1,351 @@
+package couchdb
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RedactedConfigValue replaces the values of the admins section in redacted
+// exports.
+const RedactedConfigValue = "<redacted>"
+
+// ConfigSnapshot is the configuration of a node at a point in time.
+type ConfigSnapshot struct {
+	Node    string    `json:"node"`
+	TakenAt time.Time `json:"taken_at"`
+	// Redacted is set if the values of the admins section were replaced by
+	// RedactedConfigValue, which is the case for snapshots read from a
+	// redacted export. Snapshots taken from a node are complete.
+	Redacted bool                         `json:"redacted"`
+	Config   map[string]map[string]string `json:"config"`
+}
+
+// ConfigExportOptions configures ConfigSnapshot.WriteINI and WriteJSON.
+type ConfigExportOptions struct {
+	// IncludeSecrets writes the password hashes of the admins section
+	// instead of RedactedConfigValue.
+	IncludeSecrets bool
+}
+
+// Snapshot captures the complete configuration of a node. Secrets are only
+// redacted when the snapshot is written out.
+// Example usage:
+//
+//	snapshot, err := configService.Snapshot(ctx, "couchdb@node1", WithBasicAuth("admin", "password"))
+//	if err != nil {
+//		return err
+//	}
+//	f, err := os.Create("node1.ini")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	return snapshot.WriteINI(f, nil)
+func (s *ConfigurationService) Snapshot(ctx context.Context, nodeName string, opts ...RequestOption) (*ConfigSnapshot, error) {
+	config, err := s.GetConfiguration(ctx, nodeName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConfigSnapshot{
+		Node:    nodeName,
+		TakenAt: time.Now().UTC(),
+		Config:  config,
+	}, nil
+}
+
+// SnapshotCluster captures the configuration of every cluster node reported
+// by _membership.
+func (s *ConfigurationService) SnapshotCluster(ctx context.Context, opts ...RequestOption) ([]*ConfigSnapshot, error) {
+	membership, err := s.client.Server().GetMembership(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*ConfigSnapshot, 0, len(membership.ClusterNodes))
+	for _, node := range membership.ClusterNodes {
+		snapshot, err := s.Snapshot(ctx, node, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("node %s: %w", node, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// export returns the snapshot to write, with the admins section redacted
+// unless options ask for secrets. The snapshot itself is left untouched.
+func (c *ConfigSnapshot) export(options *ConfigExportOptions) *ConfigSnapshot {
+	if (options != nil && options.IncludeSecrets) || c.Redacted {
+		return c
+	}
+
+	redacted := *c
+	redacted.Redacted = true
+	if admins, ok := c.Config["admins"]; ok {
+		redacted.Config = maps.Clone(c.Config)
+		redacted.Config["admins"] = make(map[string]string, len(admins))
+		for key := range admins {
+			redacted.Config["admins"][key] = RedactedConfigValue
+		}
+	}
+
+	return &redacted
+}
+
+// WriteJSON writes the snapshot as indented JSON. The admins section is
+// redacted unless IncludeSecrets is set.
+func (c *ConfigSnapshot) WriteJSON(w io.Writer, options *ConfigExportOptions) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(c.export(options)); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// ReadConfigSnapshotJSON reads a snapshot written by WriteJSON.
+func ReadConfigSnapshotJSON(r io.Reader) (*ConfigSnapshot, error) {
+	var snapshot ConfigSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// WriteINI writes the snapshot in the INI format of CouchDB's local.ini, with
+// sections and keys sorted. The node, time and redaction flag are written as
+// leading comments so that ReadConfigSnapshotINI can restore them. The admins
+// section is redacted unless IncludeSecrets is set.
+//
+// The INI format has no escapes, so WriteINI fails without writing anything
+// if a section, key or value would not read back unchanged, such as a value
+// spanning several lines; use WriteJSON for such configurations.
+func (c *ConfigSnapshot) WriteINI(w io.Writer, options *ConfigExportOptions) error {
+	c = c.export(options)
+
+	for section, keys := range c.Config {
+		for key, value := range keys {
+			if err := checkINIEntry(section, key, value); err != nil {
+				return fmt.Errorf("failed to write snapshot: %w", err)
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "; node: %s\n", c.Node)
+	fmt.Fprintf(bw, "; taken_at: %s\n", c.TakenAt.Format(time.RFC3339))
+	fmt.Fprintf(bw, "; redacted: %t\n", c.Redacted)
+
+	for _, section := range slices.Sorted(maps.Keys(c.Config)) {
+		fmt.Fprintf(bw, "\n[%s]\n", section)
+		for _, key := range slices.Sorted(maps.Keys(c.Config[section])) {
+			fmt.Fprintf(bw, "%s = %s\n", key, c.Config[section][key])
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// checkINIEntry reports whether an entry would not read back unchanged from
+// an INI file.
+func checkINIEntry(section, key, value string) error {
+	switch {
+	case section == "" || strings.ContainsAny(section, "]\r\n") || strings.TrimSpace(section) != section:
+		return fmt.Errorf("section %q cannot be written as INI", section)
+	case key == "" || strings.ContainsAny(key, "=\r\n") || strings.TrimSpace(key) != key ||
+		strings.ContainsAny(key[:1], "[;#"):
+		return fmt.Errorf("key %q in section %s cannot be written as INI", key, section)
+	case strings.ContainsAny(value, "\r\n") || strings.TrimSpace(value) != value:
+		return fmt.Errorf("value of %s/%s cannot be written as INI", section, key)
+	}
+	return nil
+}
+
+// ReadConfigSnapshotINI reads a snapshot written by WriteINI. It also accepts
+// CouchDB's own ini files, in which case the node and time are left empty.
+func ReadConfigSnapshotINI(r io.Reader) (*ConfigSnapshot, error) {
+	snapshot := &ConfigSnapshot{Config: map[string]map[string]string{}}
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			continue
+
+		case strings.HasPrefix(line, ";"), strings.HasPrefix(line, "#"):
+			name, value, ok := strings.Cut(strings.TrimSpace(line[1:]), ":")
+			if !ok {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			switch strings.TrimSpace(name) {
+			case "node":
+				snapshot.Node = value
+			case "taken_at":
+				if t, err := time.Parse(time.RFC3339, value); err == nil {
+					snapshot.TakenAt = t
+				}
+			case "redacted":
+				snapshot.Redacted, _ = strconv.ParseBool(value)
+			}
+
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("failed to read snapshot: line %d: malformed section %q", lineNo, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if snapshot.Config[section] == nil {
+				snapshot.Config[section] = map[string]string{}
+			}
+
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			if !ok || section == "" {
+				return nil, fmt.Errorf("failed to read snapshot: line %d: malformed entry %q", lineNo, line)
+			}
+			snapshot.Config[section][strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// DiffConfigSnapshots returns the changes that turn the configuration of from
+// into that of to, ordered by section and key. The changes carry the node of
+// from. If either snapshot is redacted, the admins section is not compared.
+func DiffConfigSnapshots(from, to *ConfigSnapshot) []ConfigChange {
+	skipAdmins := from.Redacted || to.Redacted
+
+	sections := slices.Sorted(maps.Keys(from.Config))
+	for section := range to.Config {
+		if _, ok := from.Config[section]; !ok {
+			sections = append(sections, section)
+		}
+	}
+	slices.Sort(sections)
+
+	var changes []ConfigChange
+	for _, section := range sections {
+		if skipAdmins && section == "admins" {
+			continue
+		}
+
+		keys := slices.Sorted(maps.Keys(from.Config[section]))
+		for key := range to.Config[section] {
+			if _, ok := from.Config[section][key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			old, exists := from.Config[section][key]
+			value, keep := to.Config[section][key]
+			if exists && keep && old == value {
+				continue
+			}
+			changes = append(changes, ConfigChange{
+				Node:    from.Node,
+				Section: section,
+				Key:     key,
+				Old:     old,
+				Exists:  exists,
+				New:     value,
+				Delete:  !keep,
+			})
+		}
+	}
+
+	return changes
+}
+
+// DiffSnapshot returns the changes that turn the live configuration of a node
+// into that of the snapshot.
+func (s *ConfigurationService) DiffSnapshot(ctx context.Context, nodeName string, snapshot *ConfigSnapshot, opts ...RequestOption) ([]ConfigChange, error) {
+	live, err := s.Snapshot(ctx, nodeName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return DiffConfigSnapshots(live, snapshot), nil
+}
+
+// ConfigRestoreOptions configures ConfigurationService.RestoreSnapshot.
+type ConfigRestoreOptions struct {
+	// DeleteExtra deletes the keys of the node that are not in the snapshot.
+	// By default they are left alone. It requires a complete snapshot taken
+	// by Snapshot, since CouchDB's own ini files only hold part of the
+	// configuration.
+	DeleteExtra bool
+
+	// DryRun computes the changes without making them.
+	DryRun bool
+}
+
+// RestoreSnapshot brings the configuration of a node back to a snapshot,
+// which may have been taken on another node. Only values that differ are
+// written, and the changes already made are reverted if one fails, as with
+// Apply. Keys missing from the snapshot are kept unless DeleteExtra is set.
+// The admins section of a redacted snapshot is not restored.
+// Example usage:
+//
+//	f, err := os.Open("node1.ini")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	snapshot, err := ReadConfigSnapshotINI(f)
+//	if err != nil {
+//		return err
+//	}
+//	result, err := configService.RestoreSnapshot(ctx, snapshot.Node, snapshot, nil, WithBasicAuth("admin", "password"))
+func (s *ConfigurationService) RestoreSnapshot(ctx context.Context, nodeName string, snapshot *ConfigSnapshot, options *ConfigRestoreOptions, opts ...RequestOption) (*ConfigApplyResult, error) {
+	var o ConfigRestoreOptions
+	if options != nil {
+		o = *options
+	}
+
+	if o.DeleteExtra && snapshot.TakenAt.IsZero() {
+		return nil, fmt.Errorf("cannot delete extra keys: the snapshot has no capture time and may be partial")
+	}
+
+	changes, err := s.DiffSnapshot(ctx, nodeName, snapshot, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ConfigApplyResult{}
+	for _, change := range changes {
+		if change.Delete && !o.DeleteExtra {
+			continue
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	if o.DryRun {
+		return result, nil
+	}
+
+	return result, s.applyChanges(ctx, result, opts...)
+}
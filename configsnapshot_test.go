@@ -0,0 +1,278 @@
+package couchdb
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckINIEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		key     string
+		value   string
+		wantErr bool
+	}{
+		{name: "plain", section: "chttpd", key: "port", value: "5984"},
+		{name: "empty value", section: "chttpd", key: "port", value: ""},
+		{name: "erlang term", section: "chttpd", key: "authentication_handlers", value: "{chttpd_auth, cookie_authentication_handler}"},
+		{name: "equals in value", section: "query_server_config", key: "query_limit", value: "a=b"},
+		{name: "empty section", section: "", key: "k", value: "v", wantErr: true},
+		{name: "bracket in section", section: "a]b", key: "k", value: "v", wantErr: true},
+		{name: "newline in section", section: "a\nb", key: "k", value: "v", wantErr: true},
+		{name: "padded section", section: " a", key: "k", value: "v", wantErr: true},
+		{name: "empty key", section: "s", key: "", value: "v", wantErr: true},
+		{name: "equals in key", section: "s", key: "a=b", value: "v", wantErr: true},
+		{name: "padded key", section: "s", key: "k ", value: "v", wantErr: true},
+		{name: "comment key", section: "s", key: ";k", value: "v", wantErr: true},
+		{name: "hash key", section: "s", key: "#k", value: "v", wantErr: true},
+		{name: "section-like key", section: "s", key: "[k", value: "v", wantErr: true},
+		{name: "multi-line value", section: "s", key: "k", value: "a\nb", wantErr: true},
+		{name: "carriage return in value", section: "s", key: "k", value: "a\rb", wantErr: true},
+		{name: "padded value", section: "s", key: "k", value: " v", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkINIEntry(tt.section, tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkINIEntry(%q, %q, %q) error = %v, wantErr %t", tt.section, tt.key, tt.value, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteINIRejectsEntries(t *testing.T) {
+	tests := []struct {
+		name   string
+		config map[string]map[string]string
+	}{
+		{"multi-line value", map[string]map[string]string{"s": {"k": "a\nb"}}},
+		{"padded value", map[string]map[string]string{"s": {"k": "v "}}},
+		{"equals in key", map[string]map[string]string{"s": {"a=b": "v"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot := &ConfigSnapshot{Node: "n1", Config: tt.config}
+			var buf bytes.Buffer
+			if err := snapshot.WriteINI(&buf, nil); err == nil {
+				t.Fatal("WriteINI() succeeded, want error")
+			}
+			if buf.Len() != 0 {
+				t.Errorf("WriteINI() wrote %q before failing", buf.String())
+			}
+		})
+	}
+}
+
+func TestConfigSnapshotINIRoundTrip(t *testing.T) {
+	snapshot := &ConfigSnapshot{
+		Node:    "couchdb@node1",
+		TakenAt: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		Config: map[string]map[string]string{
+			"admins":  {"admin": "-pbkdf2-abc,def,10"},
+			"chttpd":  {"port": "5984", "authentication_handlers": "{chttpd_auth, cookie_authentication_handler}"},
+			"couchdb": {"uuid": ""},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		options *ConfigExportOptions
+		want    *ConfigSnapshot
+	}{
+		{
+			name: "redacted",
+			want: &ConfigSnapshot{
+				Node:     snapshot.Node,
+				TakenAt:  snapshot.TakenAt,
+				Redacted: true,
+				Config: map[string]map[string]string{
+					"admins":  {"admin": RedactedConfigValue},
+					"chttpd":  snapshot.Config["chttpd"],
+					"couchdb": snapshot.Config["couchdb"],
+				},
+			},
+		},
+		{
+			name:    "with secrets",
+			options: &ConfigExportOptions{IncludeSecrets: true},
+			want:    snapshot,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := snapshot.WriteINI(&buf, tt.options); err != nil {
+				t.Fatalf("WriteINI() error = %v", err)
+			}
+			got, err := ReadConfigSnapshotINI(&buf)
+			if err != nil {
+				t.Fatalf("ReadConfigSnapshotINI() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("round trip = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if snapshot.Config["admins"]["admin"] == RedactedConfigValue || snapshot.Redacted {
+		t.Error("WriteINI() modified the snapshot")
+	}
+}
+
+func TestReadConfigSnapshotINI(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    *ConfigSnapshot
+		wantErr bool
+	}{
+		{
+			name: "couchdb local.ini",
+			text: `; CouchDB Configuration Settings
+
+[couchdb]
+;max_document_size = 4294967296
+uuid = abc
+
+[chttpd]
+port=5984
+bind_address = 0.0.0.0
+`,
+			want: &ConfigSnapshot{Config: map[string]map[string]string{
+				"couchdb": {"uuid": "abc"},
+				"chttpd":  {"port": "5984", "bind_address": "0.0.0.0"},
+			}},
+		},
+		{
+			name: "header comments",
+			text: `; node: couchdb@node1
+; taken_at: 2026-10-18T12:00:00Z
+; redacted: true
+
+[log]
+level = info
+`,
+			want: &ConfigSnapshot{
+				Node:     "couchdb@node1",
+				TakenAt:  time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+				Redacted: true,
+				Config:   map[string]map[string]string{"log": {"level": "info"}},
+			},
+		},
+		{
+			name: "invalid time is ignored",
+			text: "; taken_at: yesterday\n[log]\nlevel = info\n",
+			want: &ConfigSnapshot{Config: map[string]map[string]string{"log": {"level": "info"}}},
+		},
+		{
+			name: "equals in value",
+			text: "[s]\nk = a=b\n",
+			want: &ConfigSnapshot{Config: map[string]map[string]string{"s": {"k": "a=b"}}},
+		},
+		{
+			name: "empty section",
+			text: "[s]\n",
+			want: &ConfigSnapshot{Config: map[string]map[string]string{"s": {}}},
+		},
+		{name: "entry outside section", text: "k = v\n", wantErr: true},
+		{name: "entry without equals", text: "[s]\nk\n", wantErr: true},
+		{name: "unterminated section", text: "[s\nk = v\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadConfigSnapshotINI(strings.NewReader(tt.text))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ReadConfigSnapshotINI() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadConfigSnapshotINI() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadConfigSnapshotINI() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffConfigSnapshots(t *testing.T) {
+	tests := []struct {
+		name string
+		from *ConfigSnapshot
+		to   *ConfigSnapshot
+		want []ConfigChange
+	}{
+		{
+			name: "identical",
+			from: &ConfigSnapshot{Node: "n1", Config: map[string]map[string]string{"s": {"k": "v"}}},
+			to:   &ConfigSnapshot{Node: "n2", Config: map[string]map[string]string{"s": {"k": "v"}}},
+		},
+		{
+			name: "changed, added and removed",
+			from: &ConfigSnapshot{Node: "n1", Config: map[string]map[string]string{
+				"a": {"changed": "1", "removed": "x"},
+				"c": {"gone": "y"},
+			}},
+			to: &ConfigSnapshot{Node: "n2", Config: map[string]map[string]string{
+				"a": {"changed": "2", "added": "z"},
+				"b": {"new": ""},
+			}},
+			want: []ConfigChange{
+				{Node: "n1", Section: "a", Key: "added", New: "z"},
+				{Node: "n1", Section: "a", Key: "changed", Old: "1", Exists: true, New: "2"},
+				{Node: "n1", Section: "a", Key: "removed", Old: "x", Exists: true, Delete: true},
+				{Node: "n1", Section: "b", Key: "new", New: ""},
+				{Node: "n1", Section: "c", Key: "gone", Old: "y", Exists: true, Delete: true},
+			},
+		},
+		{
+			name: "admins compared when complete",
+			from: &ConfigSnapshot{Node: "n1", Config: map[string]map[string]string{"admins": {"admin": "-pbkdf2-a"}}},
+			to:   &ConfigSnapshot{Node: "n2", Config: map[string]map[string]string{"admins": {"admin": "-pbkdf2-b"}}},
+			want: []ConfigChange{
+				{Node: "n1", Section: "admins", Key: "admin", Old: "-pbkdf2-a", Exists: true, New: "-pbkdf2-b"},
+			},
+		},
+		{
+			name: "redacted admins skipped",
+			from: &ConfigSnapshot{Node: "n1", Config: map[string]map[string]string{
+				"admins": {"admin": "-pbkdf2-a", "other": "-pbkdf2-c"},
+				"log":    {"level": "info"},
+			}},
+			to: &ConfigSnapshot{Node: "n2", Redacted: true, Config: map[string]map[string]string{
+				"admins": {"admin": RedactedConfigValue},
+				"log":    {"level": "debug"},
+			}},
+			want: []ConfigChange{
+				{Node: "n1", Section: "log", Key: "level", Old: "info", Exists: true, New: "debug"},
+			},
+		},
+		{
+			name: "redacted source skips admins",
+			from: &ConfigSnapshot{Node: "n1", Redacted: true, Config: map[string]map[string]string{
+				"admins": {"admin": RedactedConfigValue},
+			}},
+			to: &ConfigSnapshot{Node: "n2", Config: map[string]map[string]string{
+				"admins": {"admin": "-pbkdf2-a", "new": "-pbkdf2-b"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffConfigSnapshots(tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffConfigSnapshots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
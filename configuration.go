@@ -8,6 +8,9 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // ConfigurationService provides methods for managing CouchDB configuration.
@@ -33,7 +36,7 @@ func NewConfigurationService(client *Client) *ConfigurationService {
 func (s *ConfigurationService) GetConfiguration(ctx context.Context, nodeName string, opts ...RequestOption) (map[string]map[string]string, error) {
 	path := fmt.Sprintf("/_node/%s/_config", url.PathEscape(nodeName))
 
-	resp, err := s.client.doRequest(ctx, http.MethodGet, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"ConfigurationService", "GetConfiguration"}, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get configuration: %w", err)
 	}
@@ -65,7 +68,7 @@ func (s *ConfigurationService) GetConfiguration(ctx context.Context, nodeName st
 func (s *ConfigurationService) GetConfigurationSection(ctx context.Context, nodeName, section string, opts ...RequestOption) (map[string]string, error) {
 	path := fmt.Sprintf("/_node/%s/_config/%s", url.PathEscape(nodeName), url.PathEscape(section))
 
-	resp, err := s.client.doRequest(ctx, http.MethodGet, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"ConfigurationService", "GetConfigurationSection"}, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get configuration section: %w", err)
 	}
@@ -97,7 +100,7 @@ func (s *ConfigurationService) GetConfigurationSection(ctx context.Context, node
 func (s *ConfigurationService) GetConfigurationValue(ctx context.Context, nodeName, section, key string, opts ...RequestOption) (string, error) {
 	path := fmt.Sprintf("/_node/%s/_config/%s/%s", url.PathEscape(nodeName), url.PathEscape(section), url.PathEscape(key))
 
-	resp, err := s.client.doRequest(ctx, http.MethodGet, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"ConfigurationService", "GetConfigurationValue"}, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return "", fmt.Errorf("failed to get configuration value: %w", err)
 	}
@@ -135,7 +138,7 @@ func (s *ConfigurationService) SetConfigurationValue(ctx context.Context, nodeNa
 		return "", fmt.Errorf("failed to marshal value: %w", err)
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodPut, path, bytes.NewReader(data), opts...)
+	resp, err := s.client.doRequest(ctx, operation{"ConfigurationService", "SetConfigurationValue"}, http.MethodPut, path, bytes.NewReader(data), opts...)
 	if err != nil {
 		return "", fmt.Errorf("failed to set configuration value: %w", err)
 	}
@@ -168,7 +171,7 @@ func (s *ConfigurationService) SetConfigurationValue(ctx context.Context, nodeNa
 func (s *ConfigurationService) DeleteConfigurationValue(ctx context.Context, nodeName, section, key string, opts ...RequestOption) (string, error) {
 	path := fmt.Sprintf("/_node/%s/_config/%s/%s", url.PathEscape(nodeName), url.PathEscape(section), url.PathEscape(key))
 
-	resp, err := s.client.doRequest(ctx, http.MethodDelete, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"ConfigurationService", "DeleteConfigurationValue"}, http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return "", fmt.Errorf("failed to delete configuration value: %w", err)
 	}
@@ -200,7 +203,7 @@ func (s *ConfigurationService) DeleteConfigurationValue(ctx context.Context, nod
 func (s *ConfigurationService) ReloadConfiguration(ctx context.Context, nodeName string, opts ...RequestOption) error {
 	path := fmt.Sprintf("/_node/%s/_config/_reload", url.PathEscape(nodeName))
 
-	resp, err := s.client.doRequest(ctx, http.MethodPost, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"ConfigurationService", "ReloadConfiguration"}, http.MethodPost, path, nil, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to reload configuration: %w", err)
 	}
@@ -251,3 +254,126 @@ func (s *ConfigurationService) UpdateAdminPassword(ctx context.Context, nodeName
 func (s *ConfigurationService) GetAdmins(ctx context.Context, nodeName string, opts ...RequestOption) (map[string]string, error) {
 	return s.GetConfigurationSection(ctx, nodeName, "admins", opts...)
 }
+
+// Typed configuration accessors.
+// CouchDB stores every value as a string; these parse and format the common
+// value types.
+
+// GetConfigurationBool gets a configuration value as a bool.
+func (s *ConfigurationService) GetConfigurationBool(ctx context.Context, nodeName, section, key string, opts ...RequestOption) (bool, error) {
+	value, err := s.GetConfigurationValue(ctx, nodeName, section, key, opts...)
+	if err != nil {
+		return false, err
+	}
+
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, fmt.Errorf("configuration value %s/%s is not a bool: %q", section, key, value)
+	}
+
+	return b, nil
+}
+
+// SetConfigurationBool sets a configuration value to "true" or "false".
+func (s *ConfigurationService) SetConfigurationBool(ctx context.Context, nodeName, section, key string, value bool, opts ...RequestOption) error {
+	_, err := s.SetConfigurationValue(ctx, nodeName, section, key, strconv.FormatBool(value), opts...)
+	return err
+}
+
+// GetConfigurationInt gets a configuration value as an int.
+func (s *ConfigurationService) GetConfigurationInt(ctx context.Context, nodeName, section, key string, opts ...RequestOption) (int, error) {
+	value, err := s.GetConfigurationValue(ctx, nodeName, section, key, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	i, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("configuration value %s/%s is not an int: %q", section, key, value)
+	}
+
+	return i, nil
+}
+
+// SetConfigurationInt sets a configuration value to an int.
+func (s *ConfigurationService) SetConfigurationInt(ctx context.Context, nodeName, section, key string, value int, opts ...RequestOption) error {
+	_, err := s.SetConfigurationValue(ctx, nodeName, section, key, strconv.Itoa(value), opts...)
+	return err
+}
+
+// GetConfigurationDuration gets a configuration value holding a number of
+// units, such as seconds for couch_httpd_auth/timeout or milliseconds for
+// replicator/connection_timeout, as a duration.
+// Example usage:
+//
+//	timeout, err := configService.GetConfigurationDuration(ctx, "_local", "chttpd_auth", "timeout", time.Second)
+func (s *ConfigurationService) GetConfigurationDuration(ctx context.Context, nodeName, section, key string, unit time.Duration, opts ...RequestOption) (time.Duration, error) {
+	n, err := s.GetConfigurationInt(ctx, nodeName, section, key, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// SetConfigurationDuration sets a configuration value to a number of units.
+// The duration must be a whole number of units.
+func (s *ConfigurationService) SetConfigurationDuration(ctx context.Context, nodeName, section, key string, value, unit time.Duration, opts ...RequestOption) error {
+	if unit <= 0 || value%unit != 0 {
+		return fmt.Errorf("duration %s is not a whole number of %s", value, unit)
+	}
+	return s.SetConfigurationInt(ctx, nodeName, section, key, int(value/unit), opts...)
+}
+
+// GetConfigurationList gets a comma-separated configuration value, such as
+// chttpd/authentication_handlers, as a list. Commas inside braces, brackets or
+// double quotes do not separate items, so Erlang terms such as
+// {chttpd_auth, cookie_authentication_handler} are kept whole. Surrounding
+// whitespace is trimmed and an empty value yields an empty list.
+func (s *ConfigurationService) GetConfigurationList(ctx context.Context, nodeName, section, key string, opts ...RequestOption) ([]string, error) {
+	value, err := s.GetConfigurationValue(ctx, nodeName, section, key, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(value) == "" {
+		return []string{}, nil
+	}
+
+	return splitConfigList(value), nil
+}
+
+// splitConfigList splits value at the commas outside of braces, brackets and
+// double quotes, trimming the items.
+func splitConfigList(value string) []string {
+	var items []string
+	depth, quoted, start := 0, false, 0
+
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case quoted:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				quoted = false
+			}
+		case c == '"':
+			quoted = true
+		case c == '{' || c == '[':
+			depth++
+		case (c == '}' || c == ']') && depth > 0:
+			depth--
+		case c == ',' && depth == 0:
+			items = append(items, strings.TrimSpace(value[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(items, strings.TrimSpace(value[start:]))
+}
+
+// SetConfigurationList sets a configuration value to a comma-separated list.
+// Items may be Erlang terms containing commas, as read by GetConfigurationList.
+func (s *ConfigurationService) SetConfigurationList(ctx context.Context, nodeName, section, key string, values []string, opts ...RequestOption) error {
+	_, err := s.SetConfigurationValue(ctx, nodeName, section, key, strings.Join(values, ", "), opts...)
+	return err
+}
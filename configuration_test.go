@@ -0,0 +1,35 @@
+package couchdb
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitConfigList(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{"single", "a", []string{"a"}},
+		{"flat", "a, b ,c", []string{"a", "b", "c"}},
+		{"empty items", "a,,b,", []string{"a", "", "b", ""}},
+		{
+			"erlang tuples",
+			"{chttpd_auth, cookie_authentication_handler}, {chttpd_auth, default_authentication_handler}",
+			[]string{"{chttpd_auth, cookie_authentication_handler}", "{chttpd_auth, default_authentication_handler}"},
+		},
+		{"nested", "{a, [b, {c, d}]}, e", []string{"{a, [b, {c, d}]}", "e"}},
+		{"quoted", `"a,b", c`, []string{`"a,b"`, "c"}},
+		{"escaped quote", `"a\",b", c`, []string{`"a\",b"`, "c"}},
+		{"unbalanced close", "a}, b", []string{"a}", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitConfigList(tt.value); !slices.Equal(got, tt.want) {
+				t.Errorf("splitConfigList(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
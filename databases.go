@@ -6,8 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 // DatabaseService provides methods for managing CouchDB databases.
@@ -70,7 +75,7 @@ type DatabaseCreateOptions struct {
 func (s *DatabaseService) GetDatabase(ctx context.Context, dbName string, opts ...RequestOption) (*DatabaseInfo, error) {
 	path := fmt.Sprintf("/%s", url.PathEscape(dbName))
 
-	resp, err := s.client.doRequest(ctx, http.MethodGet, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "GetDatabase"}, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database: %w", err)
 	}
@@ -122,7 +127,7 @@ func (s *DatabaseService) CreateDatabase(ctx context.Context, dbName string, opt
 		}
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodPut, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "CreateDatabase"}, http.MethodPut, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create database: %w", err)
 	}
@@ -153,7 +158,7 @@ func (s *DatabaseService) CreateDatabase(ctx context.Context, dbName string, opt
 func (s *DatabaseService) DeleteDatabase(ctx context.Context, dbName string, opts ...RequestOption) (*DatabaseResponse, error) {
 	path := fmt.Sprintf("/%s", url.PathEscape(dbName))
 
-	resp, err := s.client.doRequest(ctx, http.MethodDelete, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "DeleteDatabase"}, http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete database: %w", err)
 	}
@@ -184,7 +189,7 @@ func (s *DatabaseService) DeleteDatabase(ctx context.Context, dbName string, opt
 func (s *DatabaseService) DatabaseExists(ctx context.Context, dbName string, opts ...RequestOption) (bool, error) {
 	path := fmt.Sprintf("/%s", url.PathEscape(dbName))
 
-	resp, err := s.client.doRequest(ctx, http.MethodHead, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "DatabaseExists"}, http.MethodHead, path, nil, opts...)
 	if err != nil {
 		return false, fmt.Errorf("failed to check database: %w", err)
 	}
@@ -201,15 +206,83 @@ func (s *DatabaseService) DatabaseExists(ctx context.Context, dbName string, opt
 }
 
 // BulkDocItem represents a single document in a bulk operation response.
+// Failed writes have Error and Reason set instead of OK and Rev.
 type BulkDocItem struct {
-	ID  string `json:"id"`
-	OK  bool   `json:"ok"`
-	Rev string `json:"rev"`
+	ID     string `json:"id"`
+	OK     bool   `json:"ok"`
+	Rev    string `json:"rev"`
+	Error  string `json:"error,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Err returns the write error of the item as a *BulkDocError, or nil if the
+// document was written.
+func (i BulkDocItem) Err() error {
+	if i.Error == "" {
+		return nil
+	}
+	return &BulkDocError{ID: i.ID, Rev: i.Rev, Err: i.Error, Reason: i.Reason}
+}
+
+// IsConflict reports whether the write was rejected with a document update
+// conflict.
+func (i BulkDocItem) IsConflict() bool {
+	return i.Error == "conflict"
+}
+
+// BulkDocError represents a document that could not be written by a bulk
+// operation.
+type BulkDocError struct {
+	ID     string
+	Rev    string
+	Err    string
+	Reason string
+}
+
+func (e *BulkDocError) Error() string {
+	return fmt.Sprintf("failed to write %s: %s - %s", e.ID, e.Err, e.Reason)
 }
 
 // BulkDocsResponse represents the response from bulk operations.
 type BulkDocsResponse []BulkDocItem
 
+// BulkDocsResults groups the items of a bulk operation response by outcome.
+type BulkDocsResults struct {
+	Succeeded  []BulkDocItem
+	Conflicted []BulkDocItem
+	// Rejected holds writes that failed for reasons other than a conflict,
+	// such as forbidden or unauthorized from a validation function.
+	Rejected []BulkDocItem
+}
+
+// Split groups the items of the response by outcome.
+func (r BulkDocsResponse) Split() *BulkDocsResults {
+	results := &BulkDocsResults{}
+	for _, item := range r {
+		switch {
+		case item.Error == "":
+			results.Succeeded = append(results.Succeeded, item)
+		case item.IsConflict():
+			results.Conflicted = append(results.Conflicted, item)
+		default:
+			results.Rejected = append(results.Rejected, item)
+		}
+	}
+	return results
+}
+
+// Errors returns the write errors of the response, or nil if every document
+// was written.
+func (r BulkDocsResponse) Errors() []error {
+	var errs []error
+	for _, item := range r {
+		if err := item.Err(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
 // BulkInsert inserts multiple documents in a single request.
 func (s *DatabaseService) BulkInsert(ctx context.Context, dbName string, docs []map[string]any, opts ...RequestOption) (BulkDocsResponse, error) {
 	path := fmt.Sprintf("/%s/_bulk_docs", url.PathEscape(dbName))
@@ -223,7 +296,7 @@ func (s *DatabaseService) BulkInsert(ctx context.Context, dbName string, docs []
 		return nil, fmt.Errorf("failed to marshal bulk docs: %w", err)
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodPost, path, bytes.NewReader(data), opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "BulkInsert"}, http.MethodPost, path, bytes.NewReader(data), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to bulk insert: %w", err)
 	}
@@ -263,7 +336,7 @@ func (s *DatabaseService) BulkUpdate(ctx context.Context, dbName string, docs []
 		return nil, fmt.Errorf("failed to marshal bulk docs: %w", err)
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodPost, path, bytes.NewReader(data), opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "BulkUpdate"}, http.MethodPost, path, bytes.NewReader(data), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to bulk update: %w", err)
 	}
@@ -290,6 +363,699 @@ func (s *DatabaseService) BulkUpdate(ctx context.Context, dbName string, docs []
 	return bulkResp, nil
 }
 
+// ConflictMergeFunc resolves a write conflict found by BulkUpdateWithMerge.
+// current is the latest stored revision of the document, or nil if it has
+// been deleted; attempted is the document that was rejected. It returns the
+// document to write instead, or nil to give up on the document.
+type ConflictMergeFunc func(current, attempted map[string]any) (map[string]any, error)
+
+// BulkUpdateWithMerge writes multiple documents like BulkUpdate and retries
+// conflicted documents up to maxRetries times. Before each retry the latest
+// revision of every conflicted document is fetched and passed to merge along
+// with the rejected document; the merged document is written with the _rev of
+// the fetched revision.
+//
+// The response lists one item per document, in the order of docs. Documents
+// still conflicted after the last retry keep their conflict item. If a retry
+// round fails, for example because merge returns an error, the items gathered
+// so far are returned along with the error, so that writes that already
+// succeeded are still reported.
+// Example usage:
+//
+//	resp, err := dbService.BulkUpdateWithMerge(ctx, "counters", docs,
+//		func(current, attempted map[string]any) (map[string]any, error) {
+//			current["count"] = current["count"].(float64) + attempted["delta"].(float64)
+//			return current, nil
+//		}, 3)
+func (s *DatabaseService) BulkUpdateWithMerge(ctx context.Context, dbName string, docs []map[string]any, merge ConflictMergeFunc, maxRetries int, opts ...RequestOption) (BulkDocsResponse, error) {
+	bulkResp, err := s.BulkUpdate(ctx, dbName, docs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(bulkResp) != len(docs) {
+		return nil, fmt.Errorf("bulk update returned %d results for %d documents", len(bulkResp), len(docs))
+	}
+
+	results := slices.Clone(bulkResp)
+	attempted := slices.Clone(docs)
+	abandoned := make(map[int]bool)
+
+	for range maxRetries {
+		var pending []int
+		for i, item := range results {
+			if item.IsConflict() && !abandoned[i] {
+				pending = append(pending, i)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		requests := make([]BulkGetRequestDoc, len(pending))
+		for j, i := range pending {
+			requests[j] = BulkGetRequestDoc{ID: results[i].ID}
+		}
+
+		current, err := s.BulkGet(ctx, dbName, requests, nil, opts...)
+		if err != nil {
+			return results, fmt.Errorf("failed to fetch conflicted documents: %w", err)
+		}
+		if len(current.Results) != len(pending) {
+			return results, fmt.Errorf("bulk get returned %d results for %d documents", len(current.Results), len(pending))
+		}
+
+		var retry []int
+		var merged []map[string]any
+		for j, i := range pending {
+			var doc map[string]any
+			for _, d := range current.Results[j].Docs {
+				if d.OK != nil {
+					doc = d.OK
+					break
+				}
+			}
+
+			next, err := merge(doc, attempted[i])
+			if err != nil {
+				return results, fmt.Errorf("failed to merge document %s: %w", results[i].ID, err)
+			}
+			if next == nil {
+				abandoned[i] = true
+				continue
+			}
+
+			next["_id"] = results[i].ID
+			if doc != nil {
+				next["_rev"] = doc["_rev"]
+			} else {
+				delete(next, "_rev")
+			}
+
+			attempted[i] = next
+			retry = append(retry, i)
+			merged = append(merged, next)
+		}
+		if len(merged) == 0 {
+			break
+		}
+
+		retryResp, err := s.BulkUpdate(ctx, dbName, merged, opts...)
+		if err != nil {
+			return results, err
+		}
+		if len(retryResp) != len(merged) {
+			return results, fmt.Errorf("bulk update returned %d results for %d documents", len(retryResp), len(merged))
+		}
+
+		for j, i := range retry {
+			results[i] = retryResp[j]
+		}
+	}
+
+	return results, nil
+}
+
+// BulkDocsOptions represents options for the _bulk_docs endpoint.
+type BulkDocsOptions struct {
+	// NewEdits set to false stores the documents with the revisions given in
+	// their _rev and _revisions fields instead of generating new ones. This is
+	// how backups are restored and replicas are built with identical revision
+	// trees. Defaults to true.
+	NewEdits *bool `json:"new_edits,omitempty"`
+}
+
+// BulkDocs writes multiple documents in a single request.
+// Unlike BulkInsert and BulkUpdate it accepts options; with NewEdits set to
+// false, successful writes are not listed in the response.
+func (s *DatabaseService) BulkDocs(ctx context.Context, dbName string, docs []map[string]any, options *BulkDocsOptions, opts ...RequestOption) (BulkDocsResponse, error) {
+	path := fmt.Sprintf("/%s/_bulk_docs", url.PathEscape(dbName))
+
+	body := map[string]any{
+		"docs": docs,
+	}
+	if options != nil && options.NewEdits != nil {
+		body["new_edits"] = *options.NewEdits
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bulk docs: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "BulkDocs"}, http.MethodPost, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write bulk docs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(respBody, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+		}
+		return nil, fmt.Errorf("failed to write bulk docs: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var bulkResp BulkDocsResponse
+	if err := json.Unmarshal(respBody, &bulkResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return bulkResp, nil
+}
+
+// Revisions represents the _revisions field of a document: the revision
+// history from the newest revision back, as a start position and hashes.
+type Revisions struct {
+	Start int      `json:"start"`
+	IDs   []string `json:"ids"`
+}
+
+// ParseRevision splits a revision such as "3-917fa23" into its position and hash.
+func ParseRevision(rev string) (int, string, error) {
+	pos, hash, ok := strings.Cut(rev, "-")
+	if !ok || hash == "" {
+		return 0, "", fmt.Errorf("invalid revision: %q", rev)
+	}
+
+	n, err := strconv.Atoi(pos)
+	if err != nil || n < 1 {
+		return 0, "", fmt.Errorf("invalid revision: %q", rev)
+	}
+
+	return n, hash, nil
+}
+
+// NewRevisions builds a _revisions history from consecutive revisions,
+// newest first, e.g. "3-c", "2-b", "1-a".
+func NewRevisions(revs ...string) (*Revisions, error) {
+	if len(revs) == 0 {
+		return nil, fmt.Errorf("no revisions given")
+	}
+
+	revisions := &Revisions{IDs: make([]string, 0, len(revs))}
+	for i, rev := range revs {
+		pos, hash, err := ParseRevision(rev)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			revisions.Start = pos
+		} else if pos != revisions.Start-i {
+			return nil, fmt.Errorf("revision %q does not follow %q", rev, revs[i-1])
+		}
+		revisions.IDs = append(revisions.IDs, hash)
+	}
+
+	return revisions, nil
+}
+
+// Revs returns the full revisions of the history, newest first.
+func (r *Revisions) Revs() []string {
+	revs := make([]string, len(r.IDs))
+	for i, hash := range r.IDs {
+		revs[i] = fmt.Sprintf("%d-%s", r.Start-i, hash)
+	}
+	return revs
+}
+
+// RevsDiffResult represents the revisions of a document missing in a database.
+type RevsDiffResult struct {
+	Missing           []string `json:"missing"`
+	PossibleAncestors []string `json:"possible_ancestors,omitempty"`
+}
+
+// RevsDiff returns, per document ID, the given revisions that do not exist in
+// the database, along with revisions that may be their ancestors.
+// POST /{db}/_revs_diff
+func (s *DatabaseService) RevsDiff(ctx context.Context, dbName string, revs map[string][]string, opts ...RequestOption) (map[string]RevsDiffResult, error) {
+	path := fmt.Sprintf("/%s/_revs_diff", url.PathEscape(dbName))
+
+	data, err := json.Marshal(revs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal revisions: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "RevsDiff"}, http.MethodPost, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff revisions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to diff revisions: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var diff map[string]RevsDiffResult
+	if err := json.Unmarshal(body, &diff); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return diff, nil
+}
+
+// MissingRevs returns, per document ID, the given revisions that do not exist
+// in the database.
+// POST /{db}/_missing_revs
+func (s *DatabaseService) MissingRevs(ctx context.Context, dbName string, revs map[string][]string, opts ...RequestOption) (map[string][]string, error) {
+	path := fmt.Sprintf("/%s/_missing_revs", url.PathEscape(dbName))
+
+	data, err := json.Marshal(revs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal revisions: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "MissingRevs"}, http.MethodPost, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get missing revisions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get missing revisions: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var result struct {
+		MissingRevs map[string][]string `json:"missing_revs"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return result.MissingRevs, nil
+}
+
+// PurgeResponse represents the response from _purge.
+type PurgeResponse struct {
+	PurgeSeq any                 `json:"purge_seq"`
+	Purged   map[string][]string `json:"purged"`
+}
+
+// Purge permanently removes document revisions from a database. Unlike a
+// deletion, no tombstone is left behind and the revisions are not replicated.
+// revs maps document IDs to the leaf revisions to purge.
+// POST /{db}/_purge
+func (s *DatabaseService) Purge(ctx context.Context, dbName string, revs map[string][]string, opts ...RequestOption) (*PurgeResponse, error) {
+	path := fmt.Sprintf("/%s/_purge", url.PathEscape(dbName))
+
+	data, err := json.Marshal(revs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal revisions: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "Purge"}, http.MethodPost, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to purge: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var purgeResp PurgeResponse
+	if err := json.Unmarshal(body, &purgeResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &purgeResp, nil
+}
+
+// PurgeDocument permanently removes a document by purging every leaf
+// revision, including deleted and conflicting ones. It returns the purged
+// revisions.
+func (s *DatabaseService) PurgeDocument(ctx context.Context, dbName, docID string, opts ...RequestOption) ([]string, error) {
+	revs, err := s.leafRevisions(ctx, dbName, docID, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	purgeResp, err := s.Purge(ctx, dbName, map[string][]string{docID: revs}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return purgeResp.Purged[docID], nil
+}
+
+// leafRevisions returns the leaf revisions of a document using open_revs=all.
+func (s *DatabaseService) leafRevisions(ctx context.Context, dbName, docID string, opts ...RequestOption) ([]string, error) {
+	path := fmt.Sprintf("/%s/%s?open_revs=all", url.PathEscape(dbName), url.PathEscape(docID))
+
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+
+	resp, err := s.client.doRequestWithHeader(ctx, operation{"DatabaseService", "PurgeDocument"}, http.MethodGet, path, nil, header, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("document not found: %s/%s", dbName, docID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get revisions: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var results []openRevsResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	var revs []string
+	for _, result := range results {
+		if rev, ok := result.OK["_rev"].(string); ok {
+			revs = append(revs, rev)
+		}
+	}
+
+	return revs, nil
+}
+
+// GetPurgedInfosLimit returns the number of purge requests a database keeps
+// track of so that they can be applied to replicas and indexes.
+// GET /{db}/_purged_infos_limit
+func (s *DatabaseService) GetPurgedInfosLimit(ctx context.Context, dbName string, opts ...RequestOption) (int, error) {
+	path := fmt.Sprintf("/%s/_purged_infos_limit", url.PathEscape(dbName))
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "GetPurgedInfosLimit"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get purged infos limit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return 0, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return 0, fmt.Errorf("failed to get purged infos limit: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var limit int
+	if err := json.Unmarshal(body, &limit); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return limit, nil
+}
+
+// SetPurgedInfosLimit sets the number of purge requests a database keeps
+// track of.
+// PUT /{db}/_purged_infos_limit
+func (s *DatabaseService) SetPurgedInfosLimit(ctx context.Context, dbName string, limit int, opts ...RequestOption) error {
+	path := fmt.Sprintf("/%s/_purged_infos_limit", url.PathEscape(dbName))
+
+	data := []byte(strconv.Itoa(limit))
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "SetPurgedInfosLimit"}, http.MethodPut, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return fmt.Errorf("failed to set purged infos limit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return fmt.Errorf("failed to set purged infos limit: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	return nil
+}
+
+// BulkGetRequestDoc identifies a document revision to fetch with _bulk_get.
+// If Rev is empty, the winning revision is returned.
+type BulkGetRequestDoc struct {
+	ID        string   `json:"id"`
+	Rev       string   `json:"rev,omitempty"`
+	AttsSince []string `json:"atts_since,omitempty"`
+}
+
+// BulkGetOptions represents options for the _bulk_get endpoint.
+type BulkGetOptions struct {
+	Revs        bool `url:"revs,omitempty"`        // Include _revisions
+	Attachments bool `url:"attachments,omitempty"` // Include attachment bodies
+	Latest      bool `url:"latest,omitempty"`
+
+	// Multipart requests a multipart/mixed response, in which attachments are
+	// transferred as raw bytes instead of base64-encoded JSON. Attachment
+	// bodies are then returned in BulkGetDoc.Attachments.
+	Multipart bool `url:"-"`
+}
+
+// BulkGetError represents a document revision that could not be fetched.
+type BulkGetError struct {
+	ID     string `json:"id"`
+	Rev    string `json:"rev"`
+	Err    string `json:"error"`
+	Reason string `json:"reason"`
+}
+
+func (e *BulkGetError) Error() string {
+	return fmt.Sprintf("failed to get %s (%s): %s - %s", e.ID, e.Rev, e.Err, e.Reason)
+}
+
+// BulkGetDoc represents a single document revision in a _bulk_get response.
+// Exactly one of OK and Error is set.
+type BulkGetDoc struct {
+	OK    map[string]any `json:"ok,omitempty"`
+	Error *BulkGetError  `json:"error,omitempty"`
+
+	// Attachments holds attachment bodies by name for multipart responses.
+	Attachments map[string][]byte `json:"-"`
+}
+
+// BulkGetResult represents the revisions returned for one requested document.
+type BulkGetResult struct {
+	ID   string       `json:"id"`
+	Docs []BulkGetDoc `json:"docs"`
+}
+
+// BulkGetResponse represents the response from _bulk_get.
+type BulkGetResponse struct {
+	Results []BulkGetResult `json:"results"`
+}
+
+// BulkGet fetches multiple document revisions in a single request.
+// Revisions that cannot be fetched are reported per item rather than failing
+// the whole request.
+// POST /{db}/_bulk_get
+func (s *DatabaseService) BulkGet(ctx context.Context, dbName string, docs []BulkGetRequestDoc, options *BulkGetOptions, opts ...RequestOption) (*BulkGetResponse, error) {
+	path := fmt.Sprintf("/%s/_bulk_get", url.PathEscape(dbName))
+
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+
+	// Add query parameters if options provided
+	if options != nil {
+		query := url.Values{}
+		if options.Revs {
+			query.Set("revs", "true")
+		}
+		if options.Attachments {
+			query.Set("attachments", "true")
+		}
+		if options.Latest {
+			query.Set("latest", "true")
+		}
+		if len(query) > 0 {
+			path = fmt.Sprintf("%s?%s", path, query.Encode())
+		}
+		if options.Multipart {
+			header.Set("Accept", "multipart/mixed")
+		}
+	}
+
+	reqBody := map[string]any{
+		"docs": docs,
+	}
+
+	data, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bulk get request: %w", err)
+	}
+
+	resp, err := s.client.doRequestWithHeader(ctx, operation{"DatabaseService", "BulkGet"}, http.MethodPost, path, bytes.NewReader(data), header, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk get: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to bulk get: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "multipart/mixed" {
+		bulkResp, err := parseBulkGetMultipart(body, params["boundary"])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse multipart response: %w", err)
+		}
+		return bulkResp, nil
+	}
+
+	var bulkResp BulkGetResponse
+	if err := json.Unmarshal(body, &bulkResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &bulkResp, nil
+}
+
+// parseBulkGetMultipart decodes a multipart/mixed _bulk_get response. Each
+// part holds one document revision: a JSON document, a JSON error marked with
+// error="true", or a multipart/related document followed by its attachments.
+func parseBulkGetMultipart(body []byte, boundary string) (*BulkGetResponse, error) {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+
+	bulkResp := &BulkGetResponse{}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		mediaType, params, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, err
+		}
+
+		var doc BulkGetDoc
+		var docID string
+		switch {
+		case mediaType == "multipart/related":
+			doc.OK, doc.Attachments, err = parseRelatedDocument(part, params["boundary"])
+			if err != nil {
+				return nil, err
+			}
+			docID, _ = doc.OK["_id"].(string)
+		case params["error"] == "true":
+			doc.Error = &BulkGetError{}
+			if err := json.NewDecoder(part).Decode(doc.Error); err != nil {
+				return nil, err
+			}
+			docID = doc.Error.ID
+		default:
+			if err := json.NewDecoder(part).Decode(&doc.OK); err != nil {
+				return nil, err
+			}
+			docID, _ = doc.OK["_id"].(string)
+		}
+
+		// Revisions of the same document arrive consecutively.
+		n := len(bulkResp.Results)
+		if n == 0 || bulkResp.Results[n-1].ID != docID {
+			bulkResp.Results = append(bulkResp.Results, BulkGetResult{ID: docID})
+			n++
+		}
+		bulkResp.Results[n-1].Docs = append(bulkResp.Results[n-1].Docs, doc)
+	}
+
+	return bulkResp, nil
+}
+
+// parseRelatedDocument decodes a multipart/related document: a JSON body
+// followed by one part per attachment.
+func parseRelatedDocument(r io.Reader, boundary string) (map[string]any, map[string][]byte, error) {
+	reader := multipart.NewReader(r, boundary)
+
+	part, err := reader.NextPart()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var doc map[string]any
+	if err := json.NewDecoder(part).Decode(&doc); err != nil {
+		return nil, nil, err
+	}
+
+	attachments := make(map[string][]byte)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, nil, err
+		}
+		attachments[part.FileName()] = data
+	}
+
+	return doc, attachments, nil
+}
+
 // FindRequest represents a Mango query request.
 type FindRequest struct {
 	Selector       map[string]any      `json:"selector"`
@@ -332,7 +1098,7 @@ func (s *DatabaseService) Find(ctx context.Context, dbName string, query *FindRe
 		return nil, fmt.Errorf("failed to marshal find request: %w", err)
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodPost, path, bytes.NewReader(data), opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "Find"}, http.MethodPost, path, bytes.NewReader(data), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute find: %w", err)
 	}
@@ -359,6 +1125,66 @@ func (s *DatabaseService) Find(ctx context.Context, dbName string, query *FindRe
 	return &findResp, nil
 }
 
+// ExplainIndex represents the index selected for a find query.
+type ExplainIndex struct {
+	DDoc string         `json:"ddoc"` // Empty for the built-in _all_docs index
+	Name string         `json:"name"`
+	Type string         `json:"type"` // "json", "text", "special" or "nouveau"
+	Def  map[string]any `json:"def"`
+}
+
+// ExplainResponse represents the response from _explain.
+type ExplainResponse struct {
+	DBName      string         `json:"dbname"`
+	Index       ExplainIndex   `json:"index"`
+	Partitioned any            `json:"partitioned,omitempty"`
+	Selector    map[string]any `json:"selector"`
+	Opts        map[string]any `json:"opts"`
+	Limit       int            `json:"limit"`
+	Skip        int            `json:"skip"`
+	Fields      any            `json:"fields"` // "all_fields" or a list of fields
+	MRArgs      map[string]any `json:"mrargs,omitempty"`
+	Covering    bool           `json:"covering,omitempty"`
+}
+
+// Explain returns the index and options a find query would use, without
+// running it.
+// POST /{db}/_explain
+func (s *DatabaseService) Explain(ctx context.Context, dbName string, query *FindRequest, opts ...RequestOption) (*ExplainResponse, error) {
+	path := fmt.Sprintf("/%s/_explain", url.PathEscape(dbName))
+
+	data, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal find request: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "Explain"}, http.MethodPost, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain find: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to explain find: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var explainResp ExplainResponse
+	if err := json.Unmarshal(body, &explainResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &explainResp, nil
+}
+
 // AllDocsOptions represents options for the _all_docs endpoint.
 type AllDocsOptions struct {
 	Conflicts     bool     `url:"conflicts,omitempty"`
@@ -382,6 +1208,7 @@ type AllDocsRow struct {
 	Key   string         `json:"key"`
 	Value map[string]any `json:"value"`
 	Doc   map[string]any `json:"doc,omitempty"`
+	Error string         `json:"error,omitempty"` // Set for requested keys that do not exist
 }
 
 // AllDocsResponse represents the response from _all_docs.
@@ -412,62 +1239,21 @@ func (s *DatabaseService) AllDocs(ctx context.Context, dbName string, options *A
 		body := map[string]any{
 			"keys": options.Keys,
 		}
-		data, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal keys: %w", err)
+		data, marshalErr := json.Marshal(body)
+		if marshalErr != nil {
+			return nil, fmt.Errorf("failed to marshal keys: %w", marshalErr)
 		}
 
-		resp, err := s.client.doRequest(ctx, http.MethodPost, path, bytes.NewReader(data), opts...)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
+		resp, err = s.client.doRequest(ctx, operation{"DatabaseService", "AllDocs"}, http.MethodPost, path, bytes.NewReader(data), opts...)
 	} else {
 		// Build query parameters
 		if options != nil {
-			query := url.Values{}
-			if options.Conflicts {
-				query.Set("conflicts", "true")
-			}
-			if options.Descending {
-				query.Set("descending", "true")
-			}
-			if options.EndKey != "" {
-				query.Set("endkey", fmt.Sprintf(`"%s"`, options.EndKey))
-			}
-			if options.EndKeyDocID != "" {
-				query.Set("endkey_docid", options.EndKeyDocID)
-			}
-			if options.IncludeDocs {
-				query.Set("include_docs", "true")
-			}
-			if options.InclusiveEnd {
-				query.Set("inclusive_end", "true")
-			}
-			if options.Key != "" {
-				query.Set("key", fmt.Sprintf(`"%s"`, options.Key))
-			}
-			if options.Limit > 0 {
-				query.Set("limit", fmt.Sprintf("%d", options.Limit))
-			}
-			if options.Skip > 0 {
-				query.Set("skip", fmt.Sprintf("%d", options.Skip))
-			}
-			if options.StartKey != "" {
-				query.Set("startkey", fmt.Sprintf(`"%s"`, options.StartKey))
-			}
-			if options.StartKeyDocID != "" {
-				query.Set("startkey_docid", options.StartKeyDocID)
-			}
-			if options.UpdateSeq {
-				query.Set("update_seq", "true")
-			}
-			if len(query) > 0 {
+			if query := allDocsQuery(options); len(query) > 0 {
 				path = fmt.Sprintf("%s?%s", path, query.Encode())
 			}
 		}
 
-		resp, err = s.client.doRequest(ctx, http.MethodGet, path, nil, opts...)
+		resp, err = s.client.doRequest(ctx, operation{"DatabaseService", "AllDocs"}, http.MethodGet, path, nil, opts...)
 	}
 
 	if err != nil {
@@ -495,3 +1281,184 @@ func (s *DatabaseService) AllDocs(ctx context.Context, dbName string, options *A
 
 	return &allDocsResp, nil
 }
+
+// allDocsQuery builds the query parameters of an _all_docs request, except
+// for Keys.
+func allDocsQuery(options *AllDocsOptions) url.Values {
+	query := url.Values{}
+	if options.Conflicts {
+		query.Set("conflicts", "true")
+	}
+	if options.Descending {
+		query.Set("descending", "true")
+	}
+	if options.EndKey != "" {
+		query.Set("endkey", fmt.Sprintf(`"%s"`, options.EndKey))
+	}
+	if options.EndKeyDocID != "" {
+		query.Set("endkey_docid", options.EndKeyDocID)
+	}
+	if options.IncludeDocs {
+		query.Set("include_docs", "true")
+	}
+	if options.InclusiveEnd {
+		query.Set("inclusive_end", "true")
+	}
+	if options.Key != "" {
+		query.Set("key", fmt.Sprintf(`"%s"`, options.Key))
+	}
+	if options.Limit > 0 {
+		query.Set("limit", fmt.Sprintf("%d", options.Limit))
+	}
+	if options.Skip > 0 {
+		query.Set("skip", fmt.Sprintf("%d", options.Skip))
+	}
+	if options.StartKey != "" {
+		query.Set("startkey", fmt.Sprintf(`"%s"`, options.StartKey))
+	}
+	if options.StartKeyDocID != "" {
+		query.Set("startkey_docid", options.StartKeyDocID)
+	}
+	if options.UpdateSeq {
+		query.Set("update_seq", "true")
+	}
+
+	return query
+}
+
+// ChangesOptions represents options for the _changes endpoint.
+type ChangesOptions struct {
+	Conflicts   bool              `url:"conflicts,omitempty"`
+	Descending  bool              `url:"descending,omitempty"`
+	Feed        string            `url:"feed,omitempty"` // "normal" or "longpoll"
+	Filter      string            `url:"filter,omitempty"`
+	DocIDs      []string          `url:"-"`                   // POST body, implies filter=_doc_ids
+	Selector    map[string]any    `url:"-"`                   // POST body, implies filter=_selector
+	QueryParams map[string]string `url:"-"`                   // Extra parameters passed to a filter function
+	Heartbeat   int               `url:"heartbeat,omitempty"` // Milliseconds
+	IncludeDocs bool              `url:"include_docs,omitempty"`
+	Limit       int               `url:"limit,omitempty"`
+	SeqInterval int               `url:"seq_interval,omitempty"`
+	Since       string            `url:"since,omitempty"`
+	Style       string            `url:"style,omitempty"`   // "main_only" or "all_docs"
+	Timeout     int               `url:"timeout,omitempty"` // Milliseconds
+}
+
+// ChangeRev represents a leaf revision listed in a change.
+type ChangeRev struct {
+	Rev string `json:"rev"`
+}
+
+// Change represents a single row in the _changes response.
+type Change struct {
+	Seq     string         `json:"seq"`
+	ID      string         `json:"id"`
+	Changes []ChangeRev    `json:"changes"`
+	Deleted bool           `json:"deleted,omitempty"`
+	Doc     map[string]any `json:"doc,omitempty"`
+}
+
+// ChangesResponse represents the response from _changes.
+type ChangesResponse struct {
+	Results []Change `json:"results"`
+	LastSeq string   `json:"last_seq"`
+	Pending int      `json:"pending"`
+}
+
+// Changes returns a sorted list of changes made to documents in the database.
+// Only the "normal" and "longpoll" feeds are supported.
+func (s *DatabaseService) Changes(ctx context.Context, dbName string, options *ChangesOptions, opts ...RequestOption) (*ChangesResponse, error) {
+	path := fmt.Sprintf("/%s/_changes", url.PathEscape(dbName))
+
+	method := http.MethodGet
+	var reqBody io.Reader
+
+	// Build query parameters
+	if options != nil {
+		query := url.Values{}
+		for key, value := range options.QueryParams {
+			query.Set(key, value)
+		}
+		if options.Conflicts {
+			query.Set("conflicts", "true")
+		}
+		if options.Descending {
+			query.Set("descending", "true")
+		}
+		if options.Feed != "" {
+			query.Set("feed", options.Feed)
+		}
+		if options.Filter != "" {
+			query.Set("filter", options.Filter)
+		}
+		if options.Heartbeat > 0 {
+			query.Set("heartbeat", fmt.Sprintf("%d", options.Heartbeat))
+		}
+		if options.IncludeDocs {
+			query.Set("include_docs", "true")
+		}
+		if options.Limit > 0 {
+			query.Set("limit", fmt.Sprintf("%d", options.Limit))
+		}
+		if options.SeqInterval > 0 {
+			query.Set("seq_interval", fmt.Sprintf("%d", options.SeqInterval))
+		}
+		if options.Since != "" {
+			query.Set("since", options.Since)
+		}
+		if options.Style != "" {
+			query.Set("style", options.Style)
+		}
+		if options.Timeout > 0 {
+			query.Set("timeout", fmt.Sprintf("%d", options.Timeout))
+		}
+
+		// Document ID and selector filters are sent in the request body.
+		var filterBody map[string]any
+		if len(options.DocIDs) > 0 {
+			query.Set("filter", "_doc_ids")
+			filterBody = map[string]any{"doc_ids": options.DocIDs}
+		} else if options.Selector != nil {
+			query.Set("filter", "_selector")
+			filterBody = map[string]any{"selector": options.Selector}
+		}
+		if filterBody != nil {
+			data, err := json.Marshal(filterBody)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal changes filter: %w", err)
+			}
+			method = http.MethodPost
+			reqBody = bytes.NewReader(data)
+		}
+
+		if len(query) > 0 {
+			path = fmt.Sprintf("%s?%s", path, query.Encode())
+		}
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "Changes"}, method, path, reqBody, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get changes: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var changesResp ChangesResponse
+	if err := json.Unmarshal(body, &changesResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &changesResp, nil
+}
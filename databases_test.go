@@ -0,0 +1,162 @@
+package couchdb
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// crlf converts the line endings of a literal multipart body.
+func crlf(s string) []byte {
+	return []byte(strings.ReplaceAll(s, "\n", "\r\n"))
+}
+
+func TestParseBulkGetMultipart(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    []byte
+		want    *BulkGetResponse
+		wantErr bool
+	}{
+		{
+			name: "json document",
+			body: crlf(`--outer
+Content-Type: application/json
+
+{"_id":"a","_rev":"1-x","n":1}
+--outer--
+`),
+			want: &BulkGetResponse{Results: []BulkGetResult{
+				{ID: "a", Docs: []BulkGetDoc{{OK: map[string]any{"_id": "a", "_rev": "1-x", "n": float64(1)}}}},
+			}},
+		},
+		{
+			name: "error",
+			body: crlf(`--outer
+Content-Type: application/json; error="true"
+
+{"id":"b","rev":"2-y","error":"not_found","reason":"missing"}
+--outer--
+`),
+			want: &BulkGetResponse{Results: []BulkGetResult{
+				{ID: "b", Docs: []BulkGetDoc{{Error: &BulkGetError{ID: "b", Rev: "2-y", Err: "not_found", Reason: "missing"}}}},
+			}},
+		},
+		{
+			name: "attachments",
+			body: crlf(`--outer
+Content-Type: multipart/related; boundary="inner"
+
+--inner
+Content-Type: application/json
+
+{"_id":"c","_rev":"1-z","_attachments":{"a.txt":{"follows":true},"b.bin":{"follows":true}}}
+--inner
+Content-Disposition: attachment; filename="a.txt"
+Content-Type: text/plain
+
+hello
+--inner
+Content-Disposition: attachment; filename="b.bin"
+Content-Type: application/octet-stream
+
+` + "\x00\x01--inner-ish\x02" + `
+--inner--
+--outer--
+`),
+			want: &BulkGetResponse{Results: []BulkGetResult{
+				{ID: "c", Docs: []BulkGetDoc{{
+					OK: map[string]any{
+						"_id":  "c",
+						"_rev": "1-z",
+						"_attachments": map[string]any{
+							"a.txt": map[string]any{"follows": true},
+							"b.bin": map[string]any{"follows": true},
+						},
+					},
+					Attachments: map[string][]byte{
+						"a.txt": []byte("hello"),
+						"b.bin": []byte("\x00\x01--inner-ish\x02"),
+					},
+				}}},
+			}},
+		},
+		{
+			name: "revisions grouped by document",
+			body: crlf(`--outer
+Content-Type: application/json
+
+{"_id":"d","_rev":"1-a"}
+--outer
+Content-Type: application/json
+
+{"_id":"d","_rev":"1-b"}
+--outer
+Content-Type: application/json; error="true"
+
+{"id":"e","rev":"1-c","error":"not_found","reason":"missing"}
+--outer--
+`),
+			want: &BulkGetResponse{Results: []BulkGetResult{
+				{ID: "d", Docs: []BulkGetDoc{
+					{OK: map[string]any{"_id": "d", "_rev": "1-a"}},
+					{OK: map[string]any{"_id": "d", "_rev": "1-b"}},
+				}},
+				{ID: "e", Docs: []BulkGetDoc{
+					{Error: &BulkGetError{ID: "e", Rev: "1-c", Err: "not_found", Reason: "missing"}},
+				}},
+			}},
+		},
+		{
+			name: "empty",
+			body: crlf("--outer--\n"),
+			want: &BulkGetResponse{},
+		},
+		{
+			name: "malformed json",
+			body: crlf(`--outer
+Content-Type: application/json
+
+{"_id":
+--outer--
+`),
+			wantErr: true,
+		},
+		{
+			name: "missing content type",
+			body: crlf(`--outer
+
+{"_id":"a"}
+--outer--
+`),
+			wantErr: true,
+		},
+		{
+			name: "truncated",
+			body: crlf(`--outer
+Content-Type: application/json
+
+{"_id":"a"}
+`),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBulkGetMultipart(tt.body, "outer")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseBulkGetMultipart() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseBulkGetMultipart() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBulkGetMultipart() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package couchdb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+)
+
+// Database update types reported by _db_updates.
+const (
+	DBUpdateCreated = "created"
+	DBUpdateUpdated = "updated"
+	DBUpdateDeleted = "deleted"
+)
+
+// DBUpdatesOptions represents options for the _db_updates endpoint.
+type DBUpdatesOptions struct {
+	Feed      string `url:"feed,omitempty"`      // "normal", "longpoll" or "continuous"
+	Heartbeat int    `url:"heartbeat,omitempty"` // Milliseconds
+	Since     string `url:"since,omitempty"`     // A sequence or "now"
+	Timeout   int    `url:"timeout,omitempty"`   // Milliseconds
+}
+
+// DBUpdate represents an event in the _db_updates feed.
+type DBUpdate struct {
+	DBName string `json:"db_name"`
+	Type   string `json:"type"` // DBUpdateCreated, DBUpdateUpdated or DBUpdateDeleted
+	Seq    string `json:"seq"`
+}
+
+// DBUpdatesResponse represents the response from _db_updates.
+type DBUpdatesResponse struct {
+	Results []DBUpdate `json:"results"`
+	LastSeq string     `json:"last_seq"`
+}
+
+// dbUpdatesPath returns the _db_updates path for the given options.
+func dbUpdatesPath(options *DBUpdatesOptions) string {
+	path := "/_db_updates"
+
+	if options != nil {
+		query := url.Values{}
+		if options.Feed != "" {
+			query.Set("feed", options.Feed)
+		}
+		if options.Heartbeat > 0 {
+			query.Set("heartbeat", fmt.Sprintf("%d", options.Heartbeat))
+		}
+		if options.Since != "" {
+			query.Set("since", options.Since)
+		}
+		if options.Timeout > 0 {
+			query.Set("timeout", fmt.Sprintf("%d", options.Timeout))
+		}
+		if len(query) > 0 {
+			path = fmt.Sprintf("%s?%s", path, query.Encode())
+		}
+	}
+
+	return path
+}
+
+// DBUpdates returns the databases created, updated or deleted on the server.
+// Only the "normal" and "longpoll" feeds are supported; use WatchDBUpdates to
+// follow the feed.
+// GET /_db_updates
+func (s *ServerService) DBUpdates(ctx context.Context, options *DBUpdatesOptions, opts ...RequestOption) (*DBUpdatesResponse, error) {
+	if options != nil && options.Feed == "continuous" {
+		return nil, fmt.Errorf("continuous feed is not supported by DBUpdates, use WatchDBUpdates")
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "DBUpdates"}, http.MethodGet, dbUpdatesPath(options), nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get db updates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get db updates: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var updatesResp DBUpdatesResponse
+	if err := json.Unmarshal(body, &updatesResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &updatesResp, nil
+}
+
+// DBUpdatesFeed iterates over the _db_updates feed. Apart from Close, its
+// methods must not be called concurrently.
+type DBUpdatesFeed struct {
+	server  *ServerService
+	ctx     context.Context
+	cancel  context.CancelFunc
+	options DBUpdatesOptions
+	opts    []RequestOption
+	closed  atomic.Bool
+
+	mu   sync.Mutex // guards body, which Close may close concurrently
+	body io.ReadCloser
+
+	scanner *bufio.Scanner
+	pending []DBUpdate
+	lastSeq string
+	polled  bool
+
+	update DBUpdate
+	seq    string
+	err    error
+}
+
+// WatchDBUpdates follows the _db_updates feed. The feed defaults to
+// "continuous"; with "continuous" and "longpoll" the iterator reconnects from
+// the last sequence whenever the server ends a response, until ctx is done or
+// Close is called. A "normal" feed stops after one response.
+//
+// Seq returns the sequence to pass as Since to resume the feed later.
+// Example usage:
+//
+//	feed := server.WatchDBUpdates(ctx, &DBUpdatesOptions{Since: lastSeq, Heartbeat: 30000})
+//	defer feed.Close()
+//	for feed.Next() {
+//		update := feed.Update()
+//		if update.Type == DBUpdateCreated {
+//			provision(update.DBName)
+//		}
+//		lastSeq = feed.Seq()
+//	}
+//	if err := feed.Err(); err != nil {
+//		return err
+//	}
+func (s *ServerService) WatchDBUpdates(ctx context.Context, options *DBUpdatesOptions, opts ...RequestOption) *DBUpdatesFeed {
+	var o DBUpdatesOptions
+	if options != nil {
+		o = *options
+	}
+	if o.Feed == "" {
+		o.Feed = "continuous"
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	return &DBUpdatesFeed{
+		server:  s,
+		ctx:     ctx,
+		cancel:  cancel,
+		options: o,
+		opts:    opts,
+		seq:     o.Since,
+	}
+}
+
+// Next advances to the next update, blocking until one arrives. It returns
+// false when the feed ends or fails; check Err to tell them apart.
+func (f *DBUpdatesFeed) Next() bool {
+	for f.err == nil {
+		if len(f.pending) > 0 {
+			f.update = f.pending[0]
+			f.pending = f.pending[1:]
+			f.seq = f.update.Seq
+			if len(f.pending) == 0 && f.lastSeq != "" {
+				f.seq = f.lastSeq
+			}
+			return true
+		}
+
+		if f.options.Feed == "continuous" {
+			if f.next() {
+				return true
+			}
+			continue
+		}
+
+		if f.polled && f.options.Feed == "normal" {
+			return false
+		}
+		f.poll()
+	}
+
+	return false
+}
+
+// next reads the next update of a continuous feed, connecting first if
+// needed. It returns false if the caller should try again.
+func (f *DBUpdatesFeed) next() bool {
+	if f.scanner == nil {
+		if err := f.connect(); err != nil {
+			f.err = err
+			return false
+		}
+	}
+
+	for f.scanner.Scan() {
+		line := bytes.TrimSpace(f.scanner.Bytes())
+		if len(line) == 0 {
+			continue // heartbeat
+		}
+
+		var event struct {
+			DBUpdate
+			LastSeq string `json:"last_seq"`
+		}
+		if err := json.Unmarshal(line, &event); err != nil {
+			f.disconnect()
+			f.err = fmt.Errorf("failed to unmarshal db update: %w", err)
+			return false
+		}
+
+		if event.LastSeq != "" {
+			// The server ended the feed after its timeout; resume from here.
+			f.seq = event.LastSeq
+			break
+		}
+
+		f.update = event.DBUpdate
+		f.seq = event.Seq
+		return true
+	}
+
+	err := f.scanner.Err()
+	f.disconnect()
+	if f.ctx.Err() != nil {
+		f.err = f.ctx.Err()
+	} else if err != nil {
+		f.err = fmt.Errorf("failed to read db updates: %w", err)
+	}
+
+	return false
+}
+
+// connect opens a continuous feed from the current sequence.
+func (f *DBUpdatesFeed) connect() error {
+	options := f.options
+	options.Since = f.seq
+
+	resp, err := f.server.client.doRequest(f.ctx, operation{"ServerService", "WatchDBUpdates"}, http.MethodGet, dbUpdatesPath(&options), nil, f.opts...)
+	if err != nil {
+		return fmt.Errorf("failed to get db updates: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return fmt.Errorf("failed to get db updates: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	f.mu.Lock()
+	f.body = resp.Body
+	f.mu.Unlock()
+	f.scanner = bufio.NewScanner(resp.Body)
+	return nil
+}
+
+func (f *DBUpdatesFeed) disconnect() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.body != nil {
+		f.body.Close()
+	}
+	f.body = nil
+	f.scanner = nil
+}
+
+// poll fetches the next batch of a normal or longpoll feed.
+func (f *DBUpdatesFeed) poll() {
+	options := f.options
+	options.Since = f.seq
+
+	resp, err := f.server.DBUpdates(f.ctx, &options, f.opts...)
+	if err != nil {
+		if f.ctx.Err() != nil {
+			err = f.ctx.Err()
+		}
+		f.err = err
+		return
+	}
+
+	f.polled = true
+	f.pending = resp.Results
+	f.lastSeq = resp.LastSeq
+	if len(resp.Results) == 0 && resp.LastSeq != "" {
+		f.seq = resp.LastSeq
+	}
+}
+
+// Update returns the update read by the last call to Next.
+func (f *DBUpdatesFeed) Update() DBUpdate {
+	return f.update
+}
+
+// Seq returns the sequence up to which the feed has been consumed.
+func (f *DBUpdatesFeed) Seq() string {
+	return f.seq
+}
+
+// Err returns the error that ended the feed, if any. A feed stopped by Close
+// has no error.
+func (f *DBUpdatesFeed) Err() error {
+	if f.closed.Load() && errors.Is(f.err, context.Canceled) {
+		return nil
+	}
+	return f.err
+}
+
+// Close stops the feed and releases its connection. It may be called while
+// another goroutine is blocked in Next, which then returns false.
+func (f *DBUpdatesFeed) Close() error {
+	f.closed.Store(true)
+	f.cancel()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+	return nil
+}
@@ -68,79 +68,12 @@ func (s *DesignDocumentService) QueryView(ctx context.Context, dbName, ddoc, vie
 
 	// Build query parameters
 	if options != nil {
-		query := url.Values{}
-
-		if options.Conflicts {
-			query.Set("conflicts", "true")
-		}
-		if options.Descending {
-			query.Set("descending", "true")
-		}
-		if options.EndKey != nil {
-			endKeyJSON, _ := json.Marshal(options.EndKey)
-			query.Set("endkey", string(endKeyJSON))
-		}
-		if options.EndKeyDocID != "" {
-			query.Set("endkey_docid", options.EndKeyDocID)
-		}
-		if options.Group {
-			query.Set("group", "true")
-		}
-		if options.GroupLevel > 0 {
-			query.Set("group_level", fmt.Sprintf("%d", options.GroupLevel))
-		}
-		if options.IncludeDocs {
-			query.Set("include_docs", "true")
-		}
-		if options.InclusiveEnd {
-			query.Set("inclusive_end", "true")
-		}
-		if options.Key != nil {
-			keyJSON, _ := json.Marshal(options.Key)
-			query.Set("key", string(keyJSON))
-		}
-		if options.Limit > 0 {
-			query.Set("limit", fmt.Sprintf("%d", options.Limit))
-		}
-		if options.Reduce != nil {
-			if *options.Reduce {
-				query.Set("reduce", "true")
-			} else {
-				query.Set("reduce", "false")
-			}
-		}
-		if options.Skip > 0 {
-			query.Set("skip", fmt.Sprintf("%d", options.Skip))
-		}
-		if options.Sorted {
-			query.Set("sorted", "true")
-		}
-		if options.Stable {
-			query.Set("stable", "true")
-		}
-		if options.Stale != "" {
-			query.Set("stale", options.Stale)
-		}
-		if options.StartKey != nil {
-			startKeyJSON, _ := json.Marshal(options.StartKey)
-			query.Set("startkey", string(startKeyJSON))
-		}
-		if options.StartKeyDocID != "" {
-			query.Set("startkey_docid", options.StartKeyDocID)
-		}
-		if options.Update != "" {
-			query.Set("update", options.Update)
-		}
-		if options.UpdateSeq {
-			query.Set("update_seq", "true")
-		}
-
-		if len(query) > 0 {
+		if query := viewQuery(options); len(query) > 0 {
 			path = fmt.Sprintf("%s?%s", path, query.Encode())
 		}
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodGet, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DesignDocumentService", "QueryView"}, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query view: %w", err)
 	}
@@ -166,3 +99,128 @@ func (s *DesignDocumentService) QueryView(ctx context.Context, dbName, ddoc, vie
 
 	return &viewResp, nil
 }
+
+// viewQuery builds the query parameters of a view request.
+func viewQuery(options *ViewOptions) url.Values {
+	query := url.Values{}
+
+	if options.Conflicts {
+		query.Set("conflicts", "true")
+	}
+	if options.Descending {
+		query.Set("descending", "true")
+	}
+	if options.EndKey != nil {
+		endKeyJSON, _ := json.Marshal(options.EndKey)
+		query.Set("endkey", string(endKeyJSON))
+	}
+	if options.EndKeyDocID != "" {
+		query.Set("endkey_docid", options.EndKeyDocID)
+	}
+	if options.Group {
+		query.Set("group", "true")
+	}
+	if options.GroupLevel > 0 {
+		query.Set("group_level", fmt.Sprintf("%d", options.GroupLevel))
+	}
+	if options.IncludeDocs {
+		query.Set("include_docs", "true")
+	}
+	if options.InclusiveEnd {
+		query.Set("inclusive_end", "true")
+	}
+	if options.Key != nil {
+		keyJSON, _ := json.Marshal(options.Key)
+		query.Set("key", string(keyJSON))
+	}
+	if options.Limit > 0 {
+		query.Set("limit", fmt.Sprintf("%d", options.Limit))
+	}
+	if options.Reduce != nil {
+		if *options.Reduce {
+			query.Set("reduce", "true")
+		} else {
+			query.Set("reduce", "false")
+		}
+	}
+	if options.Skip > 0 {
+		query.Set("skip", fmt.Sprintf("%d", options.Skip))
+	}
+	if options.Sorted {
+		query.Set("sorted", "true")
+	}
+	if options.Stable {
+		query.Set("stable", "true")
+	}
+	if options.Stale != "" {
+		query.Set("stale", options.Stale)
+	}
+	if options.StartKey != nil {
+		startKeyJSON, _ := json.Marshal(options.StartKey)
+		query.Set("startkey", string(startKeyJSON))
+	}
+	if options.StartKeyDocID != "" {
+		query.Set("startkey_docid", options.StartKeyDocID)
+	}
+	if options.Update != "" {
+		query.Set("update", options.Update)
+	}
+	if options.UpdateSeq {
+		query.Set("update_seq", "true")
+	}
+
+	return query
+}
+
+// ViewIndexInfo represents the state of the view index of a design document.
+type ViewIndexInfo struct {
+	CompactRunning bool              `json:"compact_running"`
+	Language       string            `json:"language"`
+	PurgeSeq       any               `json:"purge_seq"`
+	Signature      string            `json:"signature"`
+	Sizes          DatabaseInfoSizes `json:"sizes"`
+	UpdaterRunning bool              `json:"updater_running"`
+	UpdateSeq      any               `json:"update_seq"`
+	WaitingClients int               `json:"waiting_clients"`
+	WaitingCommit  bool              `json:"waiting_commit"`
+}
+
+// DesignDocumentInfo represents information about a design document and its
+// view index.
+type DesignDocumentInfo struct {
+	Name      string        `json:"name"`
+	ViewIndex ViewIndexInfo `json:"view_index"`
+}
+
+// GetDesignDocumentInfo retrieves information about the view index of a
+// design document.
+// GET /{db}/_design/{ddoc}/_info
+func (s *DesignDocumentService) GetDesignDocumentInfo(ctx context.Context, dbName, ddoc string, opts ...RequestOption) (*DesignDocumentInfo, error) {
+	path := fmt.Sprintf("/%s/_design/%s/_info", url.PathEscape(dbName), url.PathEscape(ddoc))
+
+	resp, err := s.client.doRequest(ctx, operation{"DesignDocumentService", "GetDesignDocumentInfo"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get design document info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get design document info: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var info DesignDocumentInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal design document info: %w", err)
+	}
+
+	return &info, nil
+}
@@ -88,7 +88,7 @@ func (s *DocumentService) GetDocument(ctx context.Context, dbName, docID string,
 		}
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodGet, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DocumentService", "GetDocument"}, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get document: %w", err)
 	}
@@ -128,7 +128,7 @@ func (s *DocumentService) HeadDocument(ctx context.Context, dbName, docID string
 		path = fmt.Sprintf("%s?rev=%s", path, url.QueryEscape(options.Rev))
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodHead, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DocumentService", "HeadDocument"}, http.MethodHead, path, nil, opts...)
 	if err != nil {
 		return "", fmt.Errorf("failed to head document: %w", err)
 	}
@@ -174,7 +174,7 @@ func (s *DocumentService) CreateDocument(ctx context.Context, dbName string, doc
 		return nil, fmt.Errorf("failed to marshal document: %w", err)
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodPost, path, bytes.NewReader(data), opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DocumentService", "CreateDocument"}, http.MethodPost, path, bytes.NewReader(data), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create document: %w", err)
 	}
@@ -224,7 +224,7 @@ func (s *DocumentService) UpdateDocument(ctx context.Context, dbName, docID stri
 		return nil, fmt.Errorf("failed to marshal document: %w", err)
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodPut, path, bytes.NewReader(data), opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DocumentService", "UpdateDocument"}, http.MethodPut, path, bytes.NewReader(data), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update document: %w", err)
 	}
@@ -255,7 +255,7 @@ func (s *DocumentService) UpdateDocument(ctx context.Context, dbName, docID stri
 func (s *DocumentService) DeleteDocument(ctx context.Context, dbName, docID string, rev string, opts ...RequestOption) (*DocumentResponse, error) {
 	path := fmt.Sprintf("/%s/%s?rev=%s", url.PathEscape(dbName), url.PathEscape(docID), url.QueryEscape(rev))
 
-	resp, err := s.client.doRequest(ctx, http.MethodDelete, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"DocumentService", "DeleteDocument"}, http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete document: %w", err)
 	}
@@ -0,0 +1,187 @@
+package couchdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// RequestInfo describes the CouchDB operation a request belongs to.
+// It is attached to the context of every request issued by the client and can
+// be retrieved by middleware with RequestInfoFromContext.
+type RequestInfo struct {
+	Service   string // Service type that issued the request, e.g. "DocumentService".
+	Operation string // Service method that issued the request, e.g. "GetDocument".
+	Method    string // HTTP method.
+	Path      string // Request path, including the query string.
+	Database  string // Target database, if any.
+	DocID     string // Target document ID, if any.
+}
+
+// RequestResult describes the outcome of a request once its response body has
+// been fully read or closed.
+type RequestResult struct {
+	StatusCode    int    // HTTP status code, 0 if no response was received.
+	Error         string // CouchDB error code from an error response body, e.g. "conflict".
+	Reason        string // CouchDB error reason from an error response body.
+	BytesSent     int64  // Size of the request body.
+	BytesReceived int64  // Number of response body bytes read.
+	Err           error  // Transport or body read error, if any.
+}
+
+type requestInfoKey struct{}
+
+// RequestInfoFromContext returns the RequestInfo attached to a request context.
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+// systemDatabases lists the underscore-prefixed paths that name databases
+// rather than server endpoints.
+var systemDatabases = map[string]bool{
+	"_users":          true,
+	"_replicator":     true,
+	"_global_changes": true,
+}
+
+// operation identifies the service method a request is made for. Service
+// methods pass their own name; unexported helpers pass the name of the
+// method they work for.
+type operation struct {
+	service string
+	name    string
+}
+
+// newRequestInfo builds the RequestInfo for a request made for op.
+func newRequestInfo(op operation, method, path string) RequestInfo {
+	info := RequestInfo{
+		Service:   op.service,
+		Operation: op.name,
+		Method:    method,
+		Path:      path,
+	}
+
+	p, _, _ := strings.Cut(path, "?")
+	segments := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	if len(segments) == 0 || segments[0] == "" {
+		return info
+	}
+	if strings.HasPrefix(segments[0], "_") && !systemDatabases[segments[0]] {
+		return info
+	}
+	info.Database = unescapeSegment(segments[0])
+
+	if len(segments) > 1 && segments[1] != "" {
+		docID := unescapeSegment(segments[1])
+		switch {
+		case (docID == "_design" || docID == "_local") && len(segments) > 2:
+			info.DocID = docID + "/" + unescapeSegment(segments[2])
+		case strings.HasPrefix(docID, "_design/"), strings.HasPrefix(docID, "_local/"):
+			info.DocID = docID
+		case !strings.HasPrefix(docID, "_"):
+			info.DocID = docID
+		}
+	}
+
+	return info
+}
+
+func unescapeSegment(s string) string {
+	if u, err := url.PathUnescape(s); err == nil {
+		return u
+	}
+	return s
+}
+
+// maxErrorBodySize bounds how much of an error response is buffered to
+// extract the CouchDB error code.
+const maxErrorBodySize = 4096
+
+// observedBody wraps a response body, counting the bytes read and invoking a
+// callback exactly once when the body reaches EOF, fails, or is closed.
+type observedBody struct {
+	body     io.ReadCloser
+	result   RequestResult
+	errBuf   []byte
+	captured bool
+	once     sync.Once
+	done     func(RequestResult)
+}
+
+// errNilResponse is returned in place of a nil response without an error,
+// which a misbehaving round tripper or middleware may produce.
+var errNilResponse = errors.New("couchdb: round tripper returned no response and no error")
+
+// observeResponse arranges for done to be called with the request outcome once
+// the response body has been consumed. Streaming responses are therefore
+// observed for their full duration, not just until the headers arrive. A nil
+// response without an error is turned into errNilResponse.
+func observeResponse(req *http.Request, resp *http.Response, err error, done func(RequestResult)) (*http.Response, error) {
+	result := RequestResult{}
+	if req.ContentLength > 0 {
+		result.BytesSent = req.ContentLength
+	}
+
+	if err == nil && resp == nil {
+		err = errNilResponse
+	}
+	if err != nil {
+		result.Err = err
+		done(result)
+		return resp, err
+	}
+
+	result.StatusCode = resp.StatusCode
+	resp.Body = &observedBody{
+		body:     resp.Body,
+		result:   result,
+		captured: resp.StatusCode >= http.StatusBadRequest,
+		done:     done,
+	}
+
+	return resp, nil
+}
+
+func (b *observedBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.result.BytesReceived += int64(n)
+
+	if b.captured && len(b.errBuf) < maxErrorBodySize {
+		end := min(n, maxErrorBodySize-len(b.errBuf))
+		b.errBuf = append(b.errBuf, p[:end]...)
+	}
+
+	if err == io.EOF {
+		b.finish()
+	} else if err != nil {
+		b.result.Err = err
+		b.finish()
+	}
+
+	return n, err
+}
+
+func (b *observedBody) Close() error {
+	err := b.body.Close()
+	b.finish()
+	return err
+}
+
+func (b *observedBody) finish() {
+	b.once.Do(func() {
+		if len(b.errBuf) > 0 {
+			var errResp ErrorResponse
+			if json.Unmarshal(b.errBuf, &errResp) == nil {
+				b.result.Error = errResp.Error
+				b.result.Reason = errResp.Reason
+			}
+		}
+		b.done(b.result)
+	})
+}
@@ -0,0 +1,273 @@
+package couchdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// CompactDatabase starts compaction of a database, which rewrites the
+// database file without old revision bodies and unused space. Compaction
+// runs in the background; see CompactAndWait.
+// POST /{db}/_compact
+func (s *DatabaseService) CompactDatabase(ctx context.Context, dbName string, opts ...RequestOption) (*DatabaseResponse, error) {
+	path := fmt.Sprintf("/%s/_compact", url.PathEscape(dbName))
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "CompactDatabase"}, http.MethodPost, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compact database: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to compact database: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var dbResp DatabaseResponse
+	if err := json.Unmarshal(body, &dbResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &dbResp, nil
+}
+
+// CompactViews starts compaction of the view indexes of a design document.
+// POST /{db}/_compact/{ddoc}
+func (s *DatabaseService) CompactViews(ctx context.Context, dbName, ddoc string, opts ...RequestOption) (*DatabaseResponse, error) {
+	path := fmt.Sprintf("/%s/_compact/%s", url.PathEscape(dbName), url.PathEscape(ddoc))
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "CompactViews"}, http.MethodPost, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compact views: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to compact views: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var dbResp DatabaseResponse
+	if err := json.Unmarshal(body, &dbResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &dbResp, nil
+}
+
+// ViewCleanup removes view index files that are no longer used by any
+// design document of the database.
+// POST /{db}/_view_cleanup
+func (s *DatabaseService) ViewCleanup(ctx context.Context, dbName string, opts ...RequestOption) (*DatabaseResponse, error) {
+	path := fmt.Sprintf("/%s/_view_cleanup", url.PathEscape(dbName))
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "ViewCleanup"}, http.MethodPost, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clean up views: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to clean up views: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var dbResp DatabaseResponse
+	if err := json.Unmarshal(body, &dbResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &dbResp, nil
+}
+
+// EnsureFullCommit asks the server to commit pending changes to disk.
+// CouchDB 3.x commits every write and only keeps this endpoint for
+// compatibility.
+// POST /{db}/_ensure_full_commit
+func (s *DatabaseService) EnsureFullCommit(ctx context.Context, dbName string, opts ...RequestOption) (*DatabaseResponse, error) {
+	path := fmt.Sprintf("/%s/_ensure_full_commit", url.PathEscape(dbName))
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "EnsureFullCommit"}, http.MethodPost, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure full commit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to ensure full commit: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var dbResp DatabaseResponse
+	if err := json.Unmarshal(body, &dbResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &dbResp, nil
+}
+
+// GetRevsLimit returns the maximum number of revisions a database tracks for
+// each document.
+// GET /{db}/_revs_limit
+func (s *DatabaseService) GetRevsLimit(ctx context.Context, dbName string, opts ...RequestOption) (int, error) {
+	path := fmt.Sprintf("/%s/_revs_limit", url.PathEscape(dbName))
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "GetRevsLimit"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get revs limit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return 0, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return 0, fmt.Errorf("failed to get revs limit: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var limit int
+	if err := json.Unmarshal(body, &limit); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return limit, nil
+}
+
+// SetRevsLimit sets the maximum number of revisions a database tracks for
+// each document.
+// PUT /{db}/_revs_limit
+func (s *DatabaseService) SetRevsLimit(ctx context.Context, dbName string, limit int, opts ...RequestOption) error {
+	path := fmt.Sprintf("/%s/_revs_limit", url.PathEscape(dbName))
+
+	data := []byte(strconv.Itoa(limit))
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "SetRevsLimit"}, http.MethodPut, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return fmt.Errorf("failed to set revs limit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return fmt.Errorf("failed to set revs limit: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	return nil
+}
+
+// CompactionResult reports the database sizes before and after a compaction.
+type CompactionResult struct {
+	Before   DatabaseInfoSizes
+	After    DatabaseInfoSizes
+	Duration time.Duration
+}
+
+// Saved returns the number of bytes the database file shrank by.
+func (r *CompactionResult) Saved() int {
+	return r.Before.File - r.After.File
+}
+
+// CompactAndWait compacts a database and polls its info every interval until
+// CompactRunning is false again.
+//
+// Compaction starts asynchronously, so the first poll happens only after one
+// interval has passed. A non-positive interval defaults to 5s.
+// Example usage:
+//
+//	result, err := dbService.CompactAndWait(ctx, "orders", 5*time.Second)
+//	if err != nil {
+//		return err
+//	}
+//	log.Printf("reclaimed %d bytes in %s", result.Saved(), result.Duration)
+func (s *DatabaseService) CompactAndWait(ctx context.Context, dbName string, interval time.Duration, opts ...RequestOption) (*CompactionResult, error) {
+	before, err := s.GetDatabase(ctx, dbName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	start := time.Now()
+	if _, err := s.CompactDatabase(ctx, dbName, opts...); err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		info, err := s.GetDatabase(ctx, dbName, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.CompactRunning {
+			return &CompactionResult{
+				Before:   before.Sizes,
+				After:    info.Sizes,
+				Duration: time.Since(start),
+			}, nil
+		}
+	}
+}
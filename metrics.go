@@ -0,0 +1,59 @@
+package couchdb
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Metrics receives per-operation measurements from the client.
+// See the promcouchdb package for a Prometheus implementation.
+type Metrics interface {
+	// ObserveRequest is called once per request, after the response body has
+	// been fully read or closed. duration covers the entire exchange.
+	ObserveRequest(info RequestInfo, result RequestResult, duration time.Duration)
+
+	// ObserveRetry is called each time a request is retried by the client.
+	ObserveRetry(info RequestInfo)
+
+	// ObserveQueueWait is called with the time a request spent waiting for
+	// the client-side rate limiter, see WithRateLimit.
+	ObserveQueueWait(info RequestInfo, wait time.Duration)
+}
+
+// WithMetrics reports every request issued by the client to the given Metrics.
+// Metrics collection is installed as a middleware; see WithMiddleware for ordering.
+func WithMetrics(metrics Metrics) ClientOption {
+	return func(c *Client) {
+		c.metrics = metrics
+		c.middleware = append(c.middleware, metricsMiddleware(metrics))
+	}
+}
+
+func metricsMiddleware(metrics Metrics) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			info, _ := RequestInfoFromContext(req.Context())
+			start := time.Now()
+
+			resp, err := next.RoundTrip(req)
+			return observeResponse(req, resp, err, func(result RequestResult) {
+				metrics.ObserveRequest(info, result, time.Since(start))
+			})
+		})
+	}
+}
+
+// StatusClass returns the status class label for a request result:
+// "1xx" through "5xx", or "error" if no response was received.
+func StatusClass(result RequestResult) string {
+	if result.StatusCode == 0 {
+		return "error"
+	}
+	return fmt.Sprintf("%dxx", result.StatusCode/100)
+}
+
+// IsConflict reports whether the request failed with a document update conflict.
+func IsConflict(result RequestResult) bool {
+	return result.StatusCode == http.StatusConflict
+}
@@ -0,0 +1,57 @@
+package couchdb
+
+import "net/http"
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as
+// http.RoundTripper implementations.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware intercepts every request issued by the Client, regardless of the
+// service it originates from. A middleware receives the next step in the chain
+// and returns a RoundTripper wrapping it.
+//
+// A middleware may modify the request headers before calling next (the request
+// is created by the client for a single call), inspect or replace the response,
+// or short-circuit the call entirely by returning without calling next.
+//
+// Example:
+//
+//	logger := func(next http.RoundTripper) http.RoundTripper {
+//		return couchdb.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+//			log.Printf("%s %s", req.Method, req.URL.Path)
+//			return next.RoundTrip(req)
+//		})
+//	}
+//	client := couchdb.NewClient("http://localhost:5984", couchdb.WithMiddleware(logger))
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// WithMiddleware appends middleware to the client's interceptor chain.
+// Middleware run in the order they are added: the first one registered is the
+// outermost and sees the request first and the response last.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// buildTransport composes the middleware chain around the HTTP client.
+// For a multi-node client the innermost step routes the request to a node.
+func (c *Client) buildTransport() http.RoundTripper {
+	var rt http.RoundTripper = RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if c.nodes != nil {
+			return c.nodes.roundTrip(req, c.client, c.metrics)
+		}
+		return c.client.Do(req)
+	})
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		rt = c.middleware[i](rt)
+	}
+
+	return rt
+}
@@ -0,0 +1,465 @@
+package couchdb
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LoadBalancing selects how a multi-node client spreads requests across nodes.
+type LoadBalancing int
+
+const (
+	// RoundRobin cycles through healthy nodes in order.
+	RoundRobin LoadBalancing = iota
+	// LeastLatency prefers the healthy node with the lowest observed latency.
+	LeastLatency
+)
+
+// DefaultHealthCheckInterval is how often a multi-node client probes /_up on
+// each node unless configured otherwise.
+const DefaultHealthCheckInterval = 10 * time.Second
+
+// ErrNoNodes is returned when a multi-node client has no node left to try.
+var ErrNoNodes = errors.New("couchdb: no nodes available")
+
+// NodeStatus reports the state of a node in a multi-node client.
+type NodeStatus struct {
+	URL         string
+	Healthy     bool
+	Latency     time.Duration // Moving average of observed response times.
+	LastError   error
+	LastChecked time.Time
+}
+
+// WithLoadBalancing sets the load balancing strategy of a multi-node client.
+// Defaults to RoundRobin.
+func WithLoadBalancing(balancing LoadBalancing) ClientOption {
+	return func(c *Client) {
+		c.balancing = balancing
+	}
+}
+
+// WithHealthCheckInterval sets how often a multi-node client probes /_up on
+// each node. A zero or negative interval disables background health checks;
+// nodes are then only marked down and up by the outcome of regular requests.
+func WithHealthCheckInterval(interval time.Duration) ClientOption {
+	return func(c *Client) {
+		c.healthCheckInterval = interval
+	}
+}
+
+type pinnedNodeKey struct{}
+
+// PinNode returns a context that routes requests issued by a multi-node client
+// to the node with the given URL, without load balancing or failover.
+func PinNode(ctx context.Context, nodeURL string) context.Context {
+	return context.WithValue(ctx, pinnedNodeKey{}, strings.TrimRight(nodeURL, "/"))
+}
+
+// NewMultiNodeClient creates a client that routes requests across several
+// CouchDB nodes of the same cluster.
+//
+// Nodes are health-checked via GET /_up and requests fail over to another node
+// on connection errors. Idempotent requests fail over on any transport error;
+// other requests only when the connection could not be established.
+//
+// Session requests, requests authenticated with a session cookie and changes
+// feeds are kept on a single sticky node, which only moves when that node
+// becomes unreachable.
+//
+// Call Close to stop the background health checks.
+// Example usage:
+//
+//	client := NewMultiNodeClient([]string{
+//		"http://couchdb-0:5984",
+//		"http://couchdb-1:5984",
+//		"http://couchdb-2:5984",
+//	}, WithLoadBalancing(LeastLatency))
+//	defer client.Close()
+func NewMultiNodeClient(nodeURLs []string, opts ...ClientOption) *Client {
+	pool := &nodePool{
+		stop: make(chan struct{}),
+	}
+	for _, u := range nodeURLs {
+		pool.nodes = append(pool.nodes, &node{
+			url:     strings.TrimRight(u, "/"),
+			healthy: true,
+		})
+	}
+
+	var baseURL string
+	if len(pool.nodes) > 0 {
+		baseURL = pool.nodes[0].url
+		if u, err := url.Parse(baseURL); err == nil {
+			pool.basePath = u.EscapedPath()
+		}
+	}
+
+	client := &Client{
+		baseURL:             baseURL,
+		client:              http.DefaultClient,
+		nodes:               pool,
+		healthCheckInterval: DefaultHealthCheckInterval,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	pool.balancing = client.balancing
+	client.transport = client.buildTransport()
+
+	if client.healthCheckInterval > 0 && len(pool.nodes) > 0 {
+		pool.startHealthChecks(client.client, client.healthCheckInterval)
+	}
+
+	return client
+}
+
+// Nodes returns the status of each node of a multi-node client.
+// For a single-node client it returns nil.
+func (c *Client) Nodes() []NodeStatus {
+	if c.nodes == nil {
+		return nil
+	}
+	return c.nodes.status()
+}
+
+// Close releases resources held by the client, such as the background health
+// checks of a multi-node client.
+func (c *Client) Close() error {
+	if c.nodes != nil {
+		c.nodes.close()
+	}
+	return nil
+}
+
+type node struct {
+	url string
+
+	mu          sync.Mutex
+	healthy     bool
+	latency     time.Duration
+	lastErr     error
+	lastChecked time.Time
+}
+
+func (n *node) markUp(latency time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.healthy = true
+	n.lastErr = nil
+	n.lastChecked = time.Now()
+	if n.latency == 0 {
+		n.latency = latency
+	} else {
+		// Exponentially weighted moving average, alpha = 0.2.
+		n.latency = (4*n.latency + latency) / 5
+	}
+}
+
+func (n *node) markDown(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.healthy = false
+	n.lastErr = err
+	n.lastChecked = time.Now()
+}
+
+func (n *node) status() NodeStatus {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return NodeStatus{
+		URL:         n.url,
+		Healthy:     n.healthy,
+		Latency:     n.latency,
+		LastError:   n.lastErr,
+		LastChecked: n.lastChecked,
+	}
+}
+
+type nodePool struct {
+	nodes     []*node
+	balancing LoadBalancing
+	next      atomic.Uint64
+
+	// basePath is the path prefix of the first node, which requests are
+	// built against before the pool routes them.
+	basePath string
+
+	mu     sync.Mutex
+	sticky int
+
+	stop      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func (p *nodePool) status() []NodeStatus {
+	statuses := make([]NodeStatus, len(p.nodes))
+	for i, n := range p.nodes {
+		statuses[i] = n.status()
+	}
+	return statuses
+}
+
+func (p *nodePool) close() {
+	p.closeOnce.Do(func() {
+		close(p.stop)
+	})
+	p.wg.Wait()
+}
+
+func (p *nodePool) startHealthChecks(client *http.Client, interval time.Duration) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.checkAll(client, interval)
+			}
+		}
+	}()
+}
+
+func (p *nodePool) checkAll(client *http.Client, timeout time.Duration) {
+	var wg sync.WaitGroup
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			start := time.Now()
+			if err := checkUp(ctx, client, n.url); err != nil {
+				n.markDown(err)
+				return
+			}
+			n.markUp(time.Since(start))
+		}()
+	}
+	wg.Wait()
+}
+
+// checkUp probes GET /_up on the node at baseURL.
+func checkUp(ctx context.Context, client *http.Client, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/_up", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check failed with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// requiresStickyNode reports whether a request must stay on a single node.
+func requiresStickyNode(req *http.Request, path string) bool {
+	p, _, _ := strings.Cut(path, "?")
+	if strings.HasPrefix(p, "/_session") ||
+		strings.HasSuffix(p, "/_changes") ||
+		strings.HasPrefix(p, "/_db_updates") {
+		return true
+	}
+
+	_, err := req.Cookie("AuthSession")
+	return err == nil
+}
+
+// candidates returns the nodes to try for a request, in order of preference.
+// Unhealthy nodes are appended as a last resort.
+func (p *nodePool) candidates(sticky bool) []*node {
+	healthy := make([]*node, 0, len(p.nodes))
+	var unhealthy []*node
+
+	start := 0
+	if sticky {
+		p.mu.Lock()
+		start = p.sticky
+		p.mu.Unlock()
+	} else if p.balancing == RoundRobin && len(p.nodes) > 0 {
+		start = int((p.next.Add(1) - 1) % uint64(len(p.nodes)))
+	}
+
+	for i := range p.nodes {
+		n := p.nodes[(start+i)%len(p.nodes)]
+		if n.status().Healthy {
+			healthy = append(healthy, n)
+		} else {
+			unhealthy = append(unhealthy, n)
+		}
+	}
+
+	if !sticky && p.balancing == LeastLatency {
+		slices.SortStableFunc(healthy, func(a, b *node) int {
+			return cmp.Compare(a.status().Latency, b.status().Latency)
+		})
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// setSticky moves the sticky node to n.
+func (p *nodePool) setSticky(n *node) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, candidate := range p.nodes {
+		if candidate == n {
+			p.sticky = i
+			return
+		}
+	}
+}
+
+func (p *nodePool) find(nodeURL string) *node {
+	for _, n := range p.nodes {
+		if n.url == nodeURL {
+			return n
+		}
+	}
+	return nil
+}
+
+// roundTrip sends req to a node chosen by the pool, failing over to the next
+// candidate on connection errors.
+func (p *nodePool) roundTrip(req *http.Request, client *http.Client, metrics Metrics) (*http.Response, error) {
+	// Route by the request URL so that rewrites made by middleware are kept;
+	// the RequestInfo path only decides whether the request is sticky.
+	path := req.URL.RequestURI()
+	if p.basePath != "" && strings.HasPrefix(path, p.basePath+"/") {
+		path = strings.TrimPrefix(path, p.basePath)
+	}
+
+	info, _ := RequestInfoFromContext(req.Context())
+	stickyPath := info.Path
+	if stickyPath == "" {
+		stickyPath = path
+	}
+
+	var nodes []*node
+	sticky := requiresStickyNode(req, stickyPath)
+	if pinned, ok := req.Context().Value(pinnedNodeKey{}).(string); ok {
+		n := p.find(pinned)
+		if n == nil {
+			return nil, fmt.Errorf("couchdb: unknown node %q", pinned)
+		}
+		nodes = []*node{n}
+	} else {
+		nodes = p.candidates(sticky)
+	}
+
+	lastErr := ErrNoNodes
+	for i, n := range nodes {
+		if i > 0 {
+			if req.Body != nil && req.GetBody == nil {
+				break
+			}
+			if metrics != nil {
+				metrics.ObserveRetry(info)
+			}
+		}
+
+		attempt, err := rewriteRequest(req, n.url+path)
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		resp, err := client.Do(attempt)
+		if err == nil {
+			n.markUp(time.Since(start))
+			if sticky {
+				p.setSticky(n)
+			}
+			return resp, nil
+		}
+
+		lastErr = err
+		if req.Context().Err() != nil {
+			break
+		}
+		n.markDown(err)
+
+		if !canFailOver(req.Method, sticky, err) {
+			break
+		}
+	}
+
+	return nil, lastErr
+}
+
+// rewriteRequest returns a copy of req targeting rawURL, with a fresh body.
+func rewriteRequest(req *http.Request, rawURL string) (*http.Request, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt := req.Clone(req.Context())
+	attempt.URL = u
+	attempt.Host = ""
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		attempt.Body = body
+	}
+
+	return attempt, nil
+}
+
+// canFailOver reports whether a request that failed with err may be retried
+// on another node.
+func canFailOver(method string, sticky bool, err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		// Nothing reached the server; any request can safely move.
+		return true
+	}
+
+	if sticky {
+		return false
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,595 @@
+package couchdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Membership represents the nodes of a cluster.
+type Membership struct {
+	// AllNodes lists the nodes this node is connected to.
+	AllNodes []string `json:"all_nodes"`
+	// ClusterNodes lists the nodes that are members of the cluster.
+	ClusterNodes []string `json:"cluster_nodes"`
+}
+
+// Disconnected returns the cluster members this node is not connected to.
+func (m *Membership) Disconnected() []string {
+	var nodes []string
+	for _, node := range m.ClusterNodes {
+		if !slices.Contains(m.AllNodes, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// GetMembership returns the nodes of the cluster. The node names can be passed
+// to the methods that take a nodeName.
+// GET /_membership
+func (s *ServerService) GetMembership(ctx context.Context, opts ...RequestOption) (*Membership, error) {
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetMembership"}, http.MethodGet, "/_membership", nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get membership: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get membership: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var membership Membership
+	if err := json.Unmarshal(body, &membership); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal membership: %w", err)
+	}
+
+	return &membership, nil
+}
+
+// StatsHistogram represents the value of a histogram metric.
+type StatsHistogram struct {
+	N                 int          `json:"n"`
+	Min               float64      `json:"min"`
+	Max               float64      `json:"max"`
+	ArithmeticMean    float64      `json:"arithmetic_mean"`
+	GeometricMean     float64      `json:"geometric_mean"`
+	HarmonicMean      float64      `json:"harmonic_mean"`
+	Median            float64      `json:"median"`
+	Variance          float64      `json:"variance"`
+	StandardDeviation float64      `json:"standard_deviation"`
+	Skewness          float64      `json:"skewness"`
+	Kurtosis          float64      `json:"kurtosis"`
+	Percentile        [][2]float64 `json:"percentile"` // Pairs of percentile and value
+	Histogram         [][2]float64 `json:"histogram"`  // Pairs of bucket and count
+}
+
+// StatsMetric represents a single metric of a node.
+type StatsMetric struct {
+	Type string `json:"type"` // "counter", "gauge" or "histogram"
+	Desc string `json:"desc"`
+
+	// Value is set for counters and gauges.
+	Value float64 `json:"-"`
+	// Histogram is set for histograms.
+	Histogram *StatsHistogram `json:"-"`
+}
+
+// UnmarshalJSON decodes the value of the metric according to its type.
+func (m *StatsMetric) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type  string          `json:"type"`
+		Desc  string          `json:"desc"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	m.Type = raw.Type
+	m.Desc = raw.Desc
+	m.Value = 0
+	m.Histogram = nil
+
+	if len(raw.Value) == 0 || string(raw.Value) == "null" {
+		return nil
+	}
+	if raw.Type == "histogram" {
+		m.Histogram = &StatsHistogram{}
+		return json.Unmarshal(raw.Value, m.Histogram)
+	}
+	return json.Unmarshal(raw.Value, &m.Value)
+}
+
+// NodeStats holds the metrics of a node keyed by their dotted path, such as
+// "couchdb.httpd.requests".
+type NodeStats map[string]StatsMetric
+
+// UnmarshalJSON flattens the nested _stats tree.
+func (s *NodeStats) UnmarshalJSON(data []byte) error {
+	var tree map[string]json.RawMessage
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return err
+	}
+
+	stats := make(NodeStats)
+	if err := stats.flatten("", tree); err != nil {
+		return err
+	}
+
+	*s = stats
+	return nil
+}
+
+func (s NodeStats) flatten(prefix string, tree map[string]json.RawMessage) error {
+	for name, raw := range tree {
+		var node map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &node); err != nil {
+			return fmt.Errorf("failed to unmarshal stat %s%s: %w", prefix, name, err)
+		}
+
+		// Leaves carry a type and a value; everything else is a group.
+		_, hasType := node["type"]
+		_, hasValue := node["value"]
+		if hasType && hasValue {
+			var metric StatsMetric
+			if err := json.Unmarshal(raw, &metric); err != nil {
+				return fmt.Errorf("failed to unmarshal stat %s%s: %w", prefix, name, err)
+			}
+			s[prefix+name] = metric
+			continue
+		}
+
+		if err := s.flatten(prefix+name+".", node); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Get returns the metric at the given path, e.g.
+// Get("couchdb", "httpd", "requests").
+func (s NodeStats) Get(path ...string) (StatsMetric, bool) {
+	metric, ok := s[strings.Join(path, ".")]
+	return metric, ok
+}
+
+// GetNodeStats returns the metrics of a node.
+// GET /_node/{node-name}/_stats
+// Use "_local" for the node handling the request.
+func (s *ServerService) GetNodeStats(ctx context.Context, nodeName string, opts ...RequestOption) (NodeStats, error) {
+	path := fmt.Sprintf("/_node/%s/_stats", url.PathEscape(nodeName))
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetNodeStats"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node stats: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get node stats: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var stats NodeStats
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal node stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// GetNodeStat returns a single metric of a node, e.g.
+// GetNodeStat(ctx, "_local", []string{"couchdb", "httpd", "requests"}).
+// GET /_node/{node-name}/_stats/{group}/{metric}
+func (s *ServerService) GetNodeStat(ctx context.Context, nodeName string, path []string, opts ...RequestOption) (*StatsMetric, error) {
+	segments := make([]string, len(path))
+	for i, segment := range path {
+		segments[i] = url.PathEscape(segment)
+	}
+	reqPath := fmt.Sprintf("/_node/%s/_stats/%s", url.PathEscape(nodeName), strings.Join(segments, "/"))
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetNodeStat"}, http.MethodGet, reqPath, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node stat: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get node stat: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var metric StatsMetric
+	if err := json.Unmarshal(body, &metric); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal node stat: %w", err)
+	}
+	if metric.Type == "" {
+		return nil, fmt.Errorf("node stat %s is a group, not a metric", strings.Join(path, "."))
+	}
+
+	return &metric, nil
+}
+
+// PrometheusSample represents a sample in the Prometheus text format.
+type PrometheusSample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// PrometheusMetric represents a metric family in the Prometheus text format.
+// Summary and histogram samples, such as _sum and _count, belong to the
+// family they were declared with.
+type PrometheusMetric struct {
+	Name    string
+	Help    string
+	Type    string // "counter", "gauge", "summary", "histogram" or "untyped"
+	Samples []PrometheusSample
+}
+
+// GetNodePrometheus returns the metrics of a node in the Prometheus format.
+// The endpoint is served by CouchDB 3.2 and later.
+// GET /_node/{node-name}/_prometheus
+func (s *ServerService) GetNodePrometheus(ctx context.Context, nodeName string, opts ...RequestOption) ([]PrometheusMetric, error) {
+	path := fmt.Sprintf("/_node/%s/_prometheus", url.PathEscape(nodeName))
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetNodePrometheus"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get node metrics: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	metrics, err := parsePrometheusText(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse node metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// parsePrometheusText parses the Prometheus text exposition format.
+func parsePrometheusText(text string) ([]PrometheusMetric, error) {
+	var metrics []PrometheusMetric
+	index := make(map[string]int)
+
+	family := func(name string) *PrometheusMetric {
+		i, ok := index[name]
+		if !ok {
+			i = len(metrics)
+			index[name] = i
+			metrics = append(metrics, PrometheusMetric{Name: name, Type: "untyped"})
+		}
+		return &metrics[i]
+	}
+
+	for lineNo, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "#"); ok {
+			fields := strings.Fields(rest)
+			if len(fields) < 2 {
+				continue
+			}
+			switch fields[0] {
+			case "HELP":
+				// The help text follows the metric name and may contain spaces.
+				help := strings.TrimLeft(strings.TrimSpace(rest)[len("HELP"):], " \t")
+				help = strings.TrimLeft(help[len(fields[1]):], " \t")
+				family(fields[1]).Help = unescapePrometheus(help, false)
+			case "TYPE":
+				if len(fields) > 2 {
+					family(fields[1]).Type = fields[2]
+				}
+			}
+			continue
+		}
+
+		sample, err := parsePrometheusSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo+1, err)
+		}
+
+		name := sample.Name
+		if _, ok := index[name]; !ok {
+			for _, suffix := range []string{"_sum", "_count", "_bucket"} {
+				if base, ok := strings.CutSuffix(name, suffix); ok {
+					if _, ok := index[base]; ok {
+						name = base
+						break
+					}
+				}
+			}
+		}
+
+		f := family(name)
+		f.Samples = append(f.Samples, sample)
+	}
+
+	return metrics, nil
+}
+
+// parsePrometheusSample parses a line such as `name{label="value"} 1.5`,
+// optionally followed by a timestamp.
+func parsePrometheusSample(line string) (PrometheusSample, error) {
+	sample := PrometheusSample{Labels: map[string]string{}}
+	p := promScanner{s: line}
+
+	sample.Name = p.name(true)
+	if sample.Name == "" {
+		return sample, fmt.Errorf("missing metric name in %q", line)
+	}
+
+	p.space()
+	if p.consume('{') {
+		for {
+			p.space()
+			if p.consume('}') {
+				break
+			}
+
+			name := p.name(false)
+			if name == "" {
+				return sample, fmt.Errorf("malformed label name in %q", line)
+			}
+			p.space()
+			if !p.consume('=') {
+				return sample, fmt.Errorf("missing = after label %s in %q", name, line)
+			}
+			p.space()
+			value, ok := p.quoted()
+			if !ok {
+				return sample, fmt.Errorf("malformed value of label %s in %q", name, line)
+			}
+			sample.Labels[name] = value
+
+			p.space()
+			if p.consume('}') {
+				break
+			}
+			if !p.consume(',') {
+				return sample, fmt.Errorf("missing , after label %s in %q", name, line)
+			}
+		}
+	}
+
+	fields := strings.Fields(p.s[p.i:])
+	if len(fields) == 0 || len(fields) > 2 {
+		return sample, fmt.Errorf("malformed value in %q", line)
+	}
+
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, fmt.Errorf("invalid value in %q: %w", line, err)
+	}
+	sample.Value = value
+
+	return sample, nil
+}
+
+// promScanner reads the tokens of a sample line.
+type promScanner struct {
+	s string
+	i int
+}
+
+func (p *promScanner) space() {
+	for p.i < len(p.s) && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+func (p *promScanner) consume(c byte) bool {
+	if p.i < len(p.s) && p.s[p.i] == c {
+		p.i++
+		return true
+	}
+	return false
+}
+
+// name reads a metric or label name; only metric names may contain colons.
+func (p *promScanner) name(metric bool) string {
+	start := p.i
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' ||
+			p.i > start && c >= '0' && c <= '9' || metric && c == ':' {
+			p.i++
+			continue
+		}
+		break
+	}
+	return p.s[start:p.i]
+}
+
+// quoted reads a double-quoted label value, which may contain any character
+// including commas, equals signs and braces.
+func (p *promScanner) quoted() (string, bool) {
+	if !p.consume('"') {
+		return "", false
+	}
+	start := p.i
+	for p.i < len(p.s) {
+		switch p.s[p.i] {
+		case '\\':
+			p.i += 2
+		case '"':
+			value := p.s[start:p.i]
+			p.i++
+			return unescapePrometheus(value, true), true
+		default:
+			p.i++
+		}
+	}
+	return "", false
+}
+
+// unescapePrometheus resolves the escapes of help texts and, if quoted is
+// set, label values.
+func unescapePrometheus(s string, quoted bool) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch next := s[i+1]; {
+		case next == 'n':
+			b.WriteByte('\n')
+		case next == '\\', quoted && next == '"':
+			b.WriteByte(next)
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(next)
+		}
+		i++
+	}
+	return b.String()
+}
+
+// NodeSystemMemory represents the memory usage of a node's Erlang VM in bytes.
+type NodeSystemMemory struct {
+	Other         int64 `json:"other"`
+	Atom          int64 `json:"atom"`
+	AtomUsed      int64 `json:"atom_used"`
+	Processes     int64 `json:"processes"`
+	ProcessesUsed int64 `json:"processes_used"`
+	Binary        int64 `json:"binary"`
+	Code          int64 `json:"code"`
+	ETS           int64 `json:"ets"`
+}
+
+// NodeSystem represents the state of a node's Erlang VM.
+type NodeSystem struct {
+	Uptime                  int64                      `json:"uptime"` // Seconds
+	Memory                  NodeSystemMemory           `json:"memory"`
+	RunQueue                int                        `json:"run_queue"`
+	RunQueueDirtyCPU        int                        `json:"run_queue_dirty_cpu"`
+	ETSTableCount           int                        `json:"ets_table_count"`
+	ContextSwitches         int64                      `json:"context_switches"`
+	Reductions              int64                      `json:"reductions"`
+	GarbageCollectionCount  int64                      `json:"garbage_collection_count"`
+	WordsReclaimed          int64                      `json:"words_reclaimed"`
+	IOInput                 int64                      `json:"io_input"`
+	IOOutput                int64                      `json:"io_output"`
+	OSProcCount             int                        `json:"os_proc_count"`
+	StaleProcCount          int                        `json:"stale_proc_count"`
+	ProcessCount            int                        `json:"process_count"`
+	ProcessLimit            int                        `json:"process_limit"`
+	MessageQueues           map[string]json.RawMessage `json:"message_queues"` // A count, or a summary object for process groups
+	InternalReplicationJobs int                        `json:"internal_replication_jobs"`
+	Distribution            map[string]any             `json:"distribution"`
+}
+
+// GetNodeSystem returns the state of a node's Erlang VM.
+// GET /_node/{node-name}/_system
+// Use "_local" for the node handling the request.
+func (s *ServerService) GetNodeSystem(ctx context.Context, nodeName string, opts ...RequestOption) (*NodeSystem, error) {
+	path := fmt.Sprintf("/_node/%s/_system", url.PathEscape(nodeName))
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetNodeSystem"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node system: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get node system: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var system NodeSystem
+	if err := json.Unmarshal(body, &system); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal node system: %w", err)
+	}
+
+	return &system, nil
+}
+
+// RestartNode restarts a node. The node is unavailable until it is back up;
+// poll Up to wait for it.
+// POST /_node/{node-name}/_restart
+func (s *ServerService) RestartNode(ctx context.Context, nodeName string, opts ...RequestOption) error {
+	path := fmt.Sprintf("/_node/%s/_restart", url.PathEscape(nodeName))
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "RestartNode"}, http.MethodPost, path, nil, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to restart node: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return fmt.Errorf("failed to restart node: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package couchdb
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParsePrometheusSample(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    PrometheusSample
+		wantErr bool
+	}{
+		{
+			name: "no labels",
+			line: "couchdb_uptime_seconds 42",
+			want: PrometheusSample{Name: "couchdb_uptime_seconds", Labels: map[string]string{}, Value: 42},
+		},
+		{
+			name: "labels",
+			line: `couchdb_httpd_status_codes{code="200",method="GET"} 1.5`,
+			want: PrometheusSample{Name: "couchdb_httpd_status_codes", Labels: map[string]string{"code": "200", "method": "GET"}, Value: 1.5},
+		},
+		{
+			name: "separators in quoted value",
+			line: `m{path="/a,b=c}",x="y"} 1`,
+			want: PrometheusSample{Name: "m", Labels: map[string]string{"path": "/a,b=c}", "x": "y"}, Value: 1},
+		},
+		{
+			name: "escaped value",
+			line: `m{msg="say \"hi\"\\n\nnext"} 1`,
+			want: PrometheusSample{Name: "m", Labels: map[string]string{"msg": "say \"hi\"\\n\nnext"}, Value: 1},
+		},
+		{
+			name: "spaces around separators",
+			line: `m { a = "1" , b="2", } 3`,
+			want: PrometheusSample{Name: "m", Labels: map[string]string{"a": "1", "b": "2"}, Value: 3},
+		},
+		{
+			name: "empty labels",
+			line: "m{} 0",
+			want: PrometheusSample{Name: "m", Labels: map[string]string{}, Value: 0},
+		},
+		{
+			name: "timestamp",
+			line: `m{a="1"} 7 1700000000000`,
+			want: PrometheusSample{Name: "m", Labels: map[string]string{"a": "1"}, Value: 7},
+		},
+		{
+			name: "colon in name",
+			line: "job:requests:rate5m 2",
+			want: PrometheusSample{Name: "job:requests:rate5m", Labels: map[string]string{}, Value: 2},
+		},
+		{name: "missing value", line: "m", wantErr: true},
+		{name: "missing name", line: `{a="1"} 1`, wantErr: true},
+		{name: "unterminated value", line: `m{a="1} 1`, wantErr: true},
+		{name: "unquoted value", line: `m{a=1} 1`, wantErr: true},
+		{name: "missing equals", line: `m{a "1"} 1`, wantErr: true},
+		{name: "missing comma", line: `m{a="1" b="2"} 1`, wantErr: true},
+		{name: "invalid value", line: "m one", wantErr: true},
+		{name: "trailing garbage", line: "m 1 2 3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePrometheusSample(tt.line)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsePrometheusSample(%q) = %+v, want error", tt.line, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePrometheusSample(%q) error = %v", tt.line, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePrometheusSample(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePrometheusSampleSpecialValues(t *testing.T) {
+	tests := []struct {
+		line  string
+		check func(float64) bool
+	}{
+		{"m NaN", math.IsNaN},
+		{"m +Inf", func(v float64) bool { return math.IsInf(v, 1) }},
+		{"m -Inf", func(v float64) bool { return math.IsInf(v, -1) }},
+	}
+
+	for _, tt := range tests {
+		got, err := parsePrometheusSample(tt.line)
+		if err != nil || !tt.check(got.Value) {
+			t.Errorf("parsePrometheusSample(%q) = %v, %v", tt.line, got.Value, err)
+		}
+	}
+}
+
+func TestParsePrometheusText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    []PrometheusMetric
+		wantErr bool
+	}{
+		{
+			name: "help and type",
+			text: `# HELP couchdb_uptime_seconds uptime of the node
+# TYPE couchdb_uptime_seconds counter
+couchdb_uptime_seconds 42
+`,
+			want: []PrometheusMetric{{
+				Name: "couchdb_uptime_seconds",
+				Help: "uptime of the node",
+				Type: "counter",
+				Samples: []PrometheusSample{
+					{Name: "couchdb_uptime_seconds", Labels: map[string]string{}, Value: 42},
+				},
+			}},
+		},
+		{
+			name: "help escapes",
+			text: `# HELP m line one\nline two \\ "quoted"
+m 1
+`,
+			want: []PrometheusMetric{{
+				Name: "m",
+				Help: "line one\nline two \\ \"quoted\"",
+				Type: "untyped",
+				Samples: []PrometheusSample{
+					{Name: "m", Labels: map[string]string{}, Value: 1},
+				},
+			}},
+		},
+		{
+			name: "summary samples grouped",
+			text: `# TYPE latency summary
+latency{quantile="0.5"} 1
+latency{quantile="0.99"} 9
+latency_sum 20
+latency_count 10
+other_count 3
+`,
+			want: []PrometheusMetric{
+				{
+					Name: "latency",
+					Type: "summary",
+					Samples: []PrometheusSample{
+						{Name: "latency", Labels: map[string]string{"quantile": "0.5"}, Value: 1},
+						{Name: "latency", Labels: map[string]string{"quantile": "0.99"}, Value: 9},
+						{Name: "latency_sum", Labels: map[string]string{}, Value: 20},
+						{Name: "latency_count", Labels: map[string]string{}, Value: 10},
+					},
+				},
+				{
+					Name: "other_count",
+					Type: "untyped",
+					Samples: []PrometheusSample{
+						{Name: "other_count", Labels: map[string]string{}, Value: 3},
+					},
+				},
+			},
+		},
+		{
+			name: "comments and blank lines",
+			text: "# a comment\n\n   \nm 1\n",
+			want: []PrometheusMetric{{
+				Name:    "m",
+				Type:    "untyped",
+				Samples: []PrometheusSample{{Name: "m", Labels: map[string]string{}, Value: 1}},
+			}},
+		},
+		{
+			name:    "malformed sample",
+			text:    "m 1\nm{a=1} 2\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePrometheusText(tt.text)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsePrometheusText() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePrometheusText() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePrometheusText() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+module github.com/tetsuo/couchdb/otelcouchdb
+
+go 1.25.1
+
+require (
+	github.com/tetsuo/couchdb v0.0.0-20261018140432-f6626a5b868d
+	go.opentelemetry.io/otel v1.46.0
+	go.opentelemetry.io/otel/trace v1.46.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/go-logr/logr v1.4.4 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
+	go.opentelemetry.io/otel/metric v1.46.0 // indirect
+)
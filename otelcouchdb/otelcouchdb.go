@@ -0,0 +1,135 @@
+// Package otelcouchdb provides an OpenTelemetry implementation of couchdb.Tracer.
+package otelcouchdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tetsuo/couchdb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name used by the tracer.
+const ScopeName = "github.com/tetsuo/couchdb/otelcouchdb"
+
+// Attribute keys reported on every span.
+const (
+	AttrDBSystem      = attribute.Key("db.system.name")
+	AttrDBNamespace   = attribute.Key("db.namespace")
+	AttrDBOperation   = attribute.Key("db.operation.name")
+	AttrHTTPMethod    = attribute.Key("http.request.method")
+	AttrHTTPStatus    = attribute.Key("http.response.status_code")
+	AttrURLPath       = attribute.Key("url.path")
+	AttrDocID         = attribute.Key("couchdb.doc_id")
+	AttrService       = attribute.Key("couchdb.service")
+	AttrErrorCode     = attribute.Key("couchdb.error")
+	AttrErrorReason   = attribute.Key("couchdb.reason")
+	AttrBytesSent     = attribute.Key("http.request.body.size")
+	AttrBytesReceived = attribute.Key("http.response.body.size")
+)
+
+// Option configures the tracer.
+type Option func(*config)
+
+type config struct {
+	provider trace.TracerProvider
+}
+
+// WithTracerProvider sets the TracerProvider. Defaults to the global provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.provider = provider
+	}
+}
+
+// Tracer implements couchdb.Tracer using OpenTelemetry.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer creates a new Tracer.
+// Example usage:
+//
+//	client := couchdb.NewClient("http://localhost:5984",
+//		couchdb.WithTracer(otelcouchdb.NewTracer()))
+func NewTracer(opts ...Option) *Tracer {
+	cfg := &config{
+		provider: otel.GetTracerProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return &Tracer{tracer: cfg.provider.Tracer(ScopeName)}
+}
+
+// Start implements couchdb.Tracer.
+func (t *Tracer) Start(ctx context.Context, info couchdb.RequestInfo) (context.Context, couchdb.Span) {
+	name := info.Operation
+	if name == "" {
+		name = info.Method
+	}
+	if info.Service != "" {
+		name = fmt.Sprintf("%s.%s", info.Service, name)
+	}
+
+	attrs := []attribute.KeyValue{
+		AttrDBSystem.String("couchdb"),
+		AttrHTTPMethod.String(info.Method),
+		AttrURLPath.String(info.Path),
+	}
+	if info.Database != "" {
+		attrs = append(attrs, AttrDBNamespace.String(info.Database))
+	}
+	if info.Operation != "" {
+		attrs = append(attrs, AttrDBOperation.String(info.Operation))
+	}
+	if info.Service != "" {
+		attrs = append(attrs, AttrService.String(info.Service))
+	}
+	if info.DocID != "" {
+		attrs = append(attrs, AttrDocID.String(info.DocID))
+	}
+
+	ctx, span := t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+
+	return ctx, &otelSpan{span: span}
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+// End implements couchdb.Span.
+func (s *otelSpan) End(result couchdb.RequestResult) {
+	s.span.SetAttributes(
+		AttrBytesSent.Int64(result.BytesSent),
+		AttrBytesReceived.Int64(result.BytesReceived),
+	)
+
+	if result.StatusCode != 0 {
+		s.span.SetAttributes(AttrHTTPStatus.Int(result.StatusCode))
+	}
+	if result.Error != "" {
+		s.span.SetAttributes(
+			AttrErrorCode.String(result.Error),
+			AttrErrorReason.String(result.Reason),
+		)
+	}
+
+	switch {
+	case result.Err != nil:
+		s.span.RecordError(result.Err)
+		s.span.SetStatus(codes.Error, result.Err.Error())
+	case result.StatusCode >= 500:
+		s.span.SetStatus(codes.Error, fmt.Sprintf("%d %s", result.StatusCode, result.Error))
+	}
+
+	s.span.End()
+}
@@ -0,0 +1,296 @@
+package couchdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidPartition is matched by errors.Is for malformed partition names
+// and partitioned document IDs.
+var ErrInvalidPartition = errors.New("couchdb: invalid partition")
+
+// ValidatePartition checks that name can be used as a partition: it must be
+// non-empty, must not start with an underscore and must not contain a colon.
+func ValidatePartition(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("%w: empty partition name", ErrInvalidPartition)
+	case strings.HasPrefix(name, "_"):
+		return fmt.Errorf("%w: partition name %q starts with an underscore", ErrInvalidPartition, name)
+	case strings.Contains(name, ":"):
+		return fmt.Errorf("%w: partition name %q contains a colon", ErrInvalidPartition, name)
+	}
+	return nil
+}
+
+// PartitionDocID builds the ID of a document in a partitioned database,
+// "partition:docid".
+func PartitionDocID(partition, docID string) (string, error) {
+	if err := ValidatePartition(partition); err != nil {
+		return "", err
+	}
+	if docID == "" {
+		return "", fmt.Errorf("%w: empty document ID", ErrInvalidPartition)
+	}
+	return partition + ":" + docID, nil
+}
+
+// SplitPartitionDocID splits the ID of a document in a partitioned database
+// into its partition and the document ID within the partition. The partition
+// ends at the first colon.
+func SplitPartitionDocID(id string) (partition, docID string, err error) {
+	partition, docID, ok := strings.Cut(id, ":")
+	if !ok {
+		return "", "", fmt.Errorf("%w: document ID %q has no partition", ErrInvalidPartition, id)
+	}
+	if err := ValidatePartition(partition); err != nil {
+		return "", "", err
+	}
+	if docID == "" {
+		return "", "", fmt.Errorf("%w: document ID %q is empty within its partition", ErrInvalidPartition, id)
+	}
+	return partition, docID, nil
+}
+
+// PartitionInfoSizes represents the sizes of a partition.
+type PartitionInfoSizes struct {
+	Active   int `json:"active"`
+	External int `json:"external"`
+}
+
+// PartitionInfo represents information about a database partition.
+type PartitionInfo struct {
+	DBName      string             `json:"db_name"`
+	DocCount    int                `json:"doc_count"`
+	DocDelCount int                `json:"doc_del_count"`
+	Partition   string             `json:"partition"`
+	Sizes       PartitionInfoSizes `json:"sizes"`
+}
+
+// partitionPath returns the path prefix of a partition.
+func partitionPath(dbName, partition string) (string, error) {
+	if err := ValidatePartition(partition); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("/%s/_partition/%s", url.PathEscape(dbName), url.PathEscape(partition)), nil
+}
+
+// GetPartition retrieves information about a partition of a partitioned
+// database.
+// GET /{db}/_partition/{partition}
+func (s *DatabaseService) GetPartition(ctx context.Context, dbName, partition string, opts ...RequestOption) (*PartitionInfo, error) {
+	path, err := partitionPath(dbName, partition)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "GetPartition"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get partition: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get partition: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var info PartitionInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal partition info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// PartitionAllDocs lists the documents of a partition. Keys are sent as a
+// query parameter.
+// GET /{db}/_partition/{partition}/_all_docs
+func (s *DatabaseService) PartitionAllDocs(ctx context.Context, dbName, partition string, options *AllDocsOptions, opts ...RequestOption) (*AllDocsResponse, error) {
+	path, err := partitionPath(dbName, partition)
+	if err != nil {
+		return nil, err
+	}
+	path += "/_all_docs"
+
+	if options != nil {
+		query := allDocsQuery(options)
+		if len(options.Keys) > 0 {
+			keysJSON, err := json.Marshal(options.Keys)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal keys: %w", err)
+			}
+			query.Set("keys", string(keysJSON))
+		}
+		if len(query) > 0 {
+			path = fmt.Sprintf("%s?%s", path, query.Encode())
+		}
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "PartitionAllDocs"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all docs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get all docs: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var allDocsResp AllDocsResponse
+	if err := json.Unmarshal(body, &allDocsResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &allDocsResp, nil
+}
+
+// PartitionFind runs a Mango query against a single partition.
+// POST /{db}/_partition/{partition}/_find
+func (s *DatabaseService) PartitionFind(ctx context.Context, dbName, partition string, query *FindRequest, opts ...RequestOption) (*FindResponse, error) {
+	path, err := partitionPath(dbName, partition)
+	if err != nil {
+		return nil, err
+	}
+	path += "/_find"
+
+	data, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal find request: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "PartitionFind"}, http.MethodPost, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute find: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to execute find: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var findResp FindResponse
+	if err := json.Unmarshal(body, &findResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &findResp, nil
+}
+
+// PartitionExplain returns the index and options a partitioned find query
+// would use, without running it.
+// POST /{db}/_partition/{partition}/_explain
+func (s *DatabaseService) PartitionExplain(ctx context.Context, dbName, partition string, query *FindRequest, opts ...RequestOption) (*ExplainResponse, error) {
+	path, err := partitionPath(dbName, partition)
+	if err != nil {
+		return nil, err
+	}
+	path += "/_explain"
+
+	data, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal find request: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "PartitionExplain"}, http.MethodPost, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain find: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to explain find: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var explainResp ExplainResponse
+	if err := json.Unmarshal(body, &explainResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &explainResp, nil
+}
+
+// PartitionQueryView queries a view of a partitioned design document within a
+// single partition.
+// GET /{db}/_partition/{partition}/_design/{ddoc}/_view/{view}
+func (s *DesignDocumentService) PartitionQueryView(ctx context.Context, dbName, partition, ddoc, viewName string, options *ViewOptions, opts ...RequestOption) (*ViewResponse, error) {
+	path, err := partitionPath(dbName, partition)
+	if err != nil {
+		return nil, err
+	}
+	path = fmt.Sprintf("%s/_design/%s/_view/%s", path, url.PathEscape(ddoc), url.PathEscape(viewName))
+
+	if options != nil {
+		if query := viewQuery(options); len(query) > 0 {
+			path = fmt.Sprintf("%s?%s", path, query.Encode())
+		}
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"DesignDocumentService", "PartitionQueryView"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query view: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to query view: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var viewResp ViewResponse
+	if err := json.Unmarshal(body, &viewResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &viewResp, nil
+}
@@ -0,0 +1,263 @@
+package promcouchdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tetsuo/couchdb"
+)
+
+// NodeStatsOption configures the NodeStatsCollector.
+type NodeStatsOption func(*nodeStatsConfig)
+
+type nodeStatsConfig struct {
+	prefix      string
+	interval    time.Duration
+	nodes       []string
+	requestOpts []couchdb.RequestOption
+}
+
+// WithPrefix sets the prefix of the republished metric names.
+// Defaults to "couchdb_server".
+func WithPrefix(prefix string) NodeStatsOption {
+	return func(c *nodeStatsConfig) {
+		c.prefix = prefix
+	}
+}
+
+// WithPollInterval sets how often Run polls the nodes. Defaults to 30s, which
+// is also used for non-positive intervals.
+func WithPollInterval(interval time.Duration) NodeStatsOption {
+	return func(c *nodeStatsConfig) {
+		c.interval = interval
+	}
+}
+
+// WithNodes polls the given nodes instead of the cluster nodes reported by
+// _membership. Use "_local" for the node handling the request.
+func WithNodes(nodes ...string) NodeStatsOption {
+	return func(c *nodeStatsConfig) {
+		c.nodes = nodes
+	}
+}
+
+// WithRequestOptions sets the options of every stats request, usually admin
+// credentials.
+func WithRequestOptions(opts ...couchdb.RequestOption) NodeStatsOption {
+	return func(c *nodeStatsConfig) {
+		c.requestOpts = opts
+	}
+}
+
+// NodeStatsCollector polls /_node/{node}/_stats on every cluster node and
+// republishes the results as Prometheus metrics with a node label. Counters
+// become counters with a _total suffix, gauges become gauges and histograms
+// become summaries built from the server-side percentiles.
+//
+// Collect serves the results of the last poll and never blocks on CouchDB.
+type NodeStatsCollector struct {
+	client *couchdb.Client
+	cfg    nodeStatsConfig
+	upDesc *prometheus.Desc
+
+	mu    sync.RWMutex
+	stats map[string]couchdb.NodeStats
+	up    map[string]bool
+	descs map[string]*prometheus.Desc
+}
+
+// NewNodeStatsCollector creates a new NodeStatsCollector. Nothing is
+// collected until Poll or Run is called.
+// Example usage:
+//
+//	collector := promcouchdb.NewNodeStatsCollector(client,
+//		promcouchdb.WithPollInterval(15*time.Second),
+//		promcouchdb.WithRequestOptions(couchdb.WithBasicAuth("admin", "password")))
+//	prometheus.MustRegister(collector)
+//	go collector.Run(ctx)
+func NewNodeStatsCollector(client *couchdb.Client, opts ...NodeStatsOption) *NodeStatsCollector {
+	cfg := nodeStatsConfig{
+		prefix:   "couchdb_server",
+		interval: 30 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.interval <= 0 {
+		cfg.interval = 30 * time.Second
+	}
+
+	return &NodeStatsCollector{
+		client: client,
+		cfg:    cfg,
+		upDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(cfg.prefix, "", "node_up"),
+			"Whether the last poll of the node's stats succeeded.",
+			[]string{"node"}, nil,
+		),
+		stats: map[string]couchdb.NodeStats{},
+		up:    map[string]bool{},
+		descs: map[string]*prometheus.Desc{},
+	}
+}
+
+// Run polls the nodes immediately and then every poll interval until ctx is
+// done. Poll errors are reported through the node_up metric.
+func (c *NodeStatsCollector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		c.Poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches the stats of every node once. Nodes that cannot be polled are
+// reported as down and their metrics are dropped until they recover. If the
+// cluster nodes cannot be listed, every node known from earlier polls is
+// reported as down.
+func (c *NodeStatsCollector) Poll(ctx context.Context) error {
+	nodes := c.cfg.nodes
+	if len(nodes) == 0 {
+		membership, err := c.client.Server().GetMembership(ctx, c.cfg.requestOpts...)
+		if err != nil {
+			c.mu.Lock()
+			for node := range c.up {
+				c.up[node] = false
+			}
+			c.stats = map[string]couchdb.NodeStats{}
+			c.mu.Unlock()
+			return err
+		}
+		nodes = membership.ClusterNodes
+	}
+
+	stats := make(map[string]couchdb.NodeStats, len(nodes))
+	up := make(map[string]bool, len(nodes))
+	var errs []error
+
+	for _, node := range nodes {
+		nodeStats, err := c.client.Server().GetNodeStats(ctx, node, c.cfg.requestOpts...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("node %s: %w", node, err))
+			up[node] = false
+			continue
+		}
+		stats[node] = nodeStats
+		up[node] = true
+	}
+
+	c.mu.Lock()
+	c.stats = stats
+	c.up = up
+	c.mu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+// Describe implements prometheus.Collector. The metric names depend on the
+// CouchDB version, so the collector is unchecked and describes nothing.
+func (c *NodeStatsCollector) Describe(ch chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector.
+func (c *NodeStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for node, up := range c.up {
+		value := 0.0
+		if up {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.upDesc, prometheus.GaugeValue, value, node)
+	}
+
+	for node, stats := range c.stats {
+		for path, metric := range stats {
+			if m := c.metric(node, path, metric); m != nil {
+				ch <- m
+			}
+		}
+	}
+}
+
+// metric converts a CouchDB metric to a Prometheus metric. It returns nil for
+// unknown types and histograms without samples.
+func (c *NodeStatsCollector) metric(node, path string, metric couchdb.StatsMetric) prometheus.Metric {
+	name := prometheus.BuildFQName(c.cfg.prefix, "", sanitizeName(path))
+
+	switch metric.Type {
+	case "counter":
+		if !strings.HasSuffix(name, "_total") {
+			name += "_total"
+		}
+		return prometheus.MustNewConstMetric(c.desc(name, metric.Desc), prometheus.CounterValue, metric.Value, node)
+
+	case "gauge":
+		return prometheus.MustNewConstMetric(c.desc(name, metric.Desc), prometheus.GaugeValue, metric.Value, node)
+
+	case "histogram":
+		h := metric.Histogram
+		if h == nil {
+			return nil
+		}
+
+		quantiles := make(map[float64]float64, len(h.Percentile))
+		for _, p := range h.Percentile {
+			quantiles[percentileQuantile(p[0])] = p[1]
+		}
+
+		// CouchDB only reports the mean of the window, so the sum is derived.
+		return prometheus.MustNewConstSummary(c.desc(name, metric.Desc),
+			uint64(h.N), h.ArithmeticMean*float64(h.N), quantiles, node)
+	}
+
+	return nil
+}
+
+// desc returns the descriptor for name, keeping the help text of the first
+// node that reported it so that all nodes agree.
+func (c *NodeStatsCollector) desc(name, help string) *prometheus.Desc {
+	if desc, ok := c.descs[name]; ok {
+		return desc
+	}
+	if help == "" {
+		help = "CouchDB node metric " + name + "."
+	}
+	desc := prometheus.NewDesc(name, help, []string{"node"}, nil)
+	c.descs[name] = desc
+	return desc
+}
+
+// percentileQuantile converts a CouchDB percentile such as 50 or 999 to a
+// quantile such as 0.5 or 0.999.
+func percentileQuantile(p float64) float64 {
+	q := p / 100
+	for q > 1 {
+		q /= 10
+	}
+	return q
+}
+
+// sanitizeName turns a dotted stats path into a valid metric name.
+func sanitizeName(path string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		}
+		return '_'
+	}, path)
+}
@@ -0,0 +1,179 @@
+// Package promcouchdb exposes client-side CouchDB metrics as Prometheus collectors.
+package promcouchdb
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tetsuo/couchdb"
+)
+
+// Option configures the Collector.
+type Option func(*config)
+
+type config struct {
+	namespace     string
+	buckets       []float64
+	databaseLabel bool
+}
+
+// WithNamespace sets the metric namespace. Defaults to "couchdb_client".
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets the latency histogram buckets, in seconds.
+// Defaults to prometheus.DefBuckets.
+func WithBuckets(buckets []float64) Option {
+	return func(c *config) {
+		c.buckets = buckets
+	}
+}
+
+// WithoutDatabaseLabel drops the database label from all metrics.
+// Useful when databases are created per tenant and would blow up cardinality.
+func WithoutDatabaseLabel() Option {
+	return func(c *config) {
+		c.databaseLabel = false
+	}
+}
+
+// Collector implements couchdb.Metrics and prometheus.Collector.
+type Collector struct {
+	databaseLabel bool
+	requests      *prometheus.CounterVec
+	latency       *prometheus.HistogramVec
+	errors        *prometheus.CounterVec
+	conflicts     *prometheus.CounterVec
+	retries       *prometheus.CounterVec
+	queueWait     *prometheus.HistogramVec
+}
+
+// NewCollector creates a new Collector.
+// Example usage:
+//
+//	collector := promcouchdb.NewCollector()
+//	prometheus.MustRegister(collector)
+//	client := couchdb.NewClient("http://localhost:5984", couchdb.WithMetrics(collector))
+func NewCollector(opts ...Option) *Collector {
+	cfg := &config{
+		namespace:     "couchdb_client",
+		buckets:       prometheus.DefBuckets,
+		databaseLabel: true,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	labels := []string{"service", "operation"}
+	if cfg.databaseLabel {
+		labels = append(labels, "database")
+	}
+
+	return &Collector{
+		databaseLabel: cfg.databaseLabel,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "requests_total",
+			Help:      "Total number of CouchDB requests.",
+		}, slices.Concat(labels, []string{"status_class"})),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.namespace,
+			Name:      "request_duration_seconds",
+			Help:      "CouchDB request latency, including reading the response body.",
+			Buckets:   cfg.buckets,
+		}, slices.Concat(labels, []string{"status_class"})),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "errors_total",
+			Help:      "Total number of failed CouchDB requests, by CouchDB error code.",
+		}, slices.Concat(labels, []string{"error"})),
+		conflicts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "conflicts_total",
+			Help:      "Total number of document update conflicts.",
+		}, labels),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "retries_total",
+			Help:      "Total number of retried CouchDB requests.",
+		}, labels),
+		queueWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.namespace,
+			Name:      "queue_wait_seconds",
+			Help:      "Time requests spent waiting for the client-side rate limiter.",
+			Buckets:   cfg.buckets,
+		}, labels),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.latency.Describe(ch)
+	c.errors.Describe(ch)
+	c.conflicts.Describe(ch)
+	c.retries.Describe(ch)
+	c.queueWait.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.latency.Collect(ch)
+	c.errors.Collect(ch)
+	c.conflicts.Collect(ch)
+	c.retries.Collect(ch)
+	c.queueWait.Collect(ch)
+}
+
+// ObserveRequest implements couchdb.Metrics.
+func (c *Collector) ObserveRequest(info couchdb.RequestInfo, result couchdb.RequestResult, duration time.Duration) {
+	labels := c.labels(info)
+	statusLabels := slices.Concat(labels, []string{couchdb.StatusClass(result)})
+
+	c.requests.WithLabelValues(statusLabels...).Inc()
+	c.latency.WithLabelValues(statusLabels...).Observe(duration.Seconds())
+
+	if couchdb.IsConflict(result) {
+		c.conflicts.WithLabelValues(labels...).Inc()
+	}
+
+	if result.Err != nil || result.StatusCode >= 400 {
+		c.errors.WithLabelValues(slices.Concat(labels, []string{errorLabel(result)})...).Inc()
+	}
+}
+
+// ObserveRetry implements couchdb.Metrics.
+func (c *Collector) ObserveRetry(info couchdb.RequestInfo) {
+	c.retries.WithLabelValues(c.labels(info)...).Inc()
+}
+
+// ObserveQueueWait implements couchdb.Metrics.
+func (c *Collector) ObserveQueueWait(info couchdb.RequestInfo, wait time.Duration) {
+	c.queueWait.WithLabelValues(c.labels(info)...).Observe(wait.Seconds())
+}
+
+func (c *Collector) labels(info couchdb.RequestInfo) []string {
+	labels := []string{info.Service, info.Operation}
+	if c.databaseLabel {
+		labels = append(labels, info.Database)
+	}
+	return labels
+}
+
+func errorLabel(result couchdb.RequestResult) string {
+	switch {
+	case result.Error != "":
+		return result.Error
+	case result.StatusCode != 0:
+		return strconv.Itoa(result.StatusCode)
+	default:
+		return "transport"
+	}
+}
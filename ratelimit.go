@@ -0,0 +1,184 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit configures client-side throttling of requests.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained request rate of the token bucket.
+	// Zero disables rate limiting.
+	RequestsPerSecond float64
+
+	// Burst is the token bucket size. Defaults to 1 when RequestsPerSecond is set.
+	Burst int
+
+	// MaxInFlight caps the number of concurrent requests. A request occupies
+	// its slot until its response body is closed. Zero means no cap.
+	MaxInFlight int
+
+	// PerDatabase applies the limits to each database separately instead of
+	// to the client as a whole. Server-level requests share one set of limits.
+	PerDatabase bool
+}
+
+// WithRateLimit throttles requests issued by the client. Requests wait for a
+// token and a free in-flight slot, or until their context is done. The time
+// spent waiting is reported to the client's Metrics.
+//
+// Rate limiting is installed as a middleware; see WithMiddleware for ordering.
+// Example usage:
+//
+//	client := NewClient("http://localhost:5984", WithRateLimit(RateLimit{
+//		RequestsPerSecond: 50,
+//		Burst:             10,
+//		MaxInFlight:       4,
+//	}))
+func WithRateLimit(limit RateLimit) ClientOption {
+	return func(c *Client) {
+		l := &rateLimiter{
+			limit:    limit,
+			limiters: make(map[string]*limiter),
+		}
+		c.middleware = append(c.middleware, l.middleware(c))
+	}
+}
+
+type rateLimiter struct {
+	limit RateLimit
+
+	mu       sync.Mutex
+	limiters map[string]*limiter
+}
+
+// get returns the limiter that applies to the given database.
+func (r *rateLimiter) get(dbName string) *limiter {
+	if !r.limit.PerDatabase {
+		dbName = ""
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l, ok := r.limiters[dbName]
+	if !ok {
+		l = newLimiter(r.limit)
+		r.limiters[dbName] = l
+	}
+
+	return l
+}
+
+func (r *rateLimiter) middleware(c *Client) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			info, _ := RequestInfoFromContext(req.Context())
+			l := r.get(info.Database)
+
+			start := time.Now()
+			release, err := l.acquire(req.Context())
+			if c.metrics != nil {
+				c.metrics.ObserveQueueWait(info, time.Since(start))
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := next.RoundTrip(req)
+			return observeResponse(req, resp, err, func(RequestResult) {
+				release()
+			})
+		})
+	}
+}
+
+// limiter combines a token bucket with an in-flight semaphore.
+type limiter struct {
+	bucket *tokenBucket
+	slots  chan struct{}
+}
+
+func newLimiter(limit RateLimit) *limiter {
+	l := &limiter{}
+
+	if limit.RequestsPerSecond > 0 {
+		burst := max(limit.Burst, 1)
+		l.bucket = &tokenBucket{
+			rate:   limit.RequestsPerSecond,
+			burst:  float64(burst),
+			tokens: float64(burst),
+			last:   time.Now(),
+		}
+	}
+
+	if limit.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, limit.MaxInFlight)
+	}
+
+	return l
+}
+
+// acquire waits for a token and an in-flight slot. The returned function
+// releases the slot and must be called exactly once.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// tokenBucket is a token bucket rate limiter.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// wait takes a token, sleeping until one is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	// Reserve the token up front; a negative balance queues later callers
+	// behind this one.
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the reservation back.
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,352 @@
+package couchdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ReplicatorDatabase is the database holding persistent replication documents.
+const ReplicatorDatabase = "_replicator"
+
+// ReplicatorService provides methods for running replications, either one-shot
+// via /_replicate or persistently via documents in the _replicator database.
+// See: https://docs.couchdb.org/en/stable/replication/replicator.html
+type ReplicatorService struct {
+	client *Client
+}
+
+// NewReplicatorService creates a new ReplicatorService.
+func NewReplicatorService(client *Client) *ReplicatorService {
+	return &ReplicatorService{client: client}
+}
+
+// ReplicationBasicAuth represents basic auth credentials for a replication endpoint.
+type ReplicationBasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// ReplicationAuth represents the auth object of a replication endpoint.
+type ReplicationAuth struct {
+	Basic *ReplicationBasicAuth `json:"basic,omitempty"`
+}
+
+// ReplicationEndpoint represents the source or target of a replication.
+type ReplicationEndpoint struct {
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Auth    *ReplicationAuth  `json:"auth,omitempty"`
+}
+
+// UnmarshalJSON accepts both the object form and the plain URL string form of an endpoint.
+func (e *ReplicationEndpoint) UnmarshalJSON(data []byte) error {
+	var rawURL string
+	if err := json.Unmarshal(data, &rawURL); err == nil {
+		*e = ReplicationEndpoint{URL: rawURL}
+		return nil
+	}
+
+	type endpoint ReplicationEndpoint
+	return json.Unmarshal(data, (*endpoint)(e))
+}
+
+// ReplicationRequest represents a replication, used both as the /_replicate
+// request body and as the body of a _replicator document.
+type ReplicationRequest struct {
+	Source             ReplicationEndpoint `json:"source"`
+	Target             ReplicationEndpoint `json:"target"`
+	CreateTarget       bool                `json:"create_target,omitempty"`
+	CreateTargetParams map[string]any      `json:"create_target_params,omitempty"`
+	Continuous         bool                `json:"continuous,omitempty"`
+	DocIDs             []string            `json:"doc_ids,omitempty"`
+	Selector           map[string]any      `json:"selector,omitempty"`
+	Filter             string              `json:"filter,omitempty"`
+	QueryParams        map[string]any      `json:"query_params,omitempty"`
+	SinceSeq           string              `json:"since_seq,omitempty"`
+	CheckpointInterval int                 `json:"checkpoint_interval,omitempty"` // Milliseconds.
+	UseCheckpoints     *bool               `json:"use_checkpoints,omitempty"`
+	WinningRevsOnly    bool                `json:"winning_revs_only,omitempty"`
+	Cancel             bool                `json:"cancel,omitempty"`         // Only for /_replicate.
+	ReplicationID      string              `json:"replication_id,omitempty"` // Cancel by ID, only for /_replicate.
+}
+
+// ReplicationHistory represents an entry in the history of a one-shot replication.
+type ReplicationHistory struct {
+	SessionID        string `json:"session_id"`
+	StartTime        string `json:"start_time"`
+	EndTime          string `json:"end_time"`
+	StartLastSeq     any    `json:"start_last_seq"`
+	EndLastSeq       any    `json:"end_last_seq"`
+	RecordedSeq      any    `json:"recorded_seq"`
+	MissingChecked   int    `json:"missing_checked"`
+	MissingFound     int    `json:"missing_found"`
+	DocsRead         int    `json:"docs_read"`
+	DocsWritten      int    `json:"docs_written"`
+	DocWriteFailures int    `json:"doc_write_failures"`
+}
+
+// ReplicateResponse represents the response from /_replicate.
+// One-shot replications report their History; continuous replications and
+// cancellations report the LocalID of the replication.
+type ReplicateResponse struct {
+	OK                   bool                 `json:"ok"`
+	NoChanges            bool                 `json:"no_changes,omitempty"`
+	SessionID            string               `json:"session_id,omitempty"`
+	SourceLastSeq        any                  `json:"source_last_seq,omitempty"`
+	ReplicationIDVersion int                  `json:"replication_id_version,omitempty"`
+	History              []ReplicationHistory `json:"history,omitempty"`
+	LocalID              string               `json:"_local_id,omitempty"`
+}
+
+// Replicate starts, runs or cancels a replication.
+// POST /_replicate
+// One-shot replications block until the replication completes; continuous
+// replications return once started.
+func (s *ReplicatorService) Replicate(ctx context.Context, replication *ReplicationRequest, opts ...RequestOption) (*ReplicateResponse, error) {
+	data, err := json.Marshal(replication)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal replication: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ReplicatorService", "Replicate"}, http.MethodPost, "/_replicate", bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replicate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to replicate: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var replicateResp ReplicateResponse
+	if err := json.Unmarshal(body, &replicateResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &replicateResp, nil
+}
+
+// ReplicationDocument represents a document in the _replicator database.
+// The _replication_* fields are maintained by the server.
+type ReplicationDocument struct {
+	ID  string `json:"_id,omitempty"`
+	Rev string `json:"_rev,omitempty"`
+	ReplicationRequest
+
+	ReplicationState       string         `json:"_replication_state,omitempty"`
+	ReplicationStateTime   string         `json:"_replication_state_time,omitempty"`
+	ReplicationStateReason string         `json:"_replication_state_reason,omitempty"`
+	ReplicationID          string         `json:"_replication_id,omitempty"`
+	ReplicationStats       map[string]any `json:"_replication_stats,omitempty"`
+}
+
+// CreateReplication creates a persistent replication document.
+// PUT /_replicator/{docid}
+func (s *ReplicatorService) CreateReplication(ctx context.Context, docID string, replication *ReplicationRequest, opts ...RequestOption) (*DocumentResponse, error) {
+	path := fmt.Sprintf("/%s/%s", ReplicatorDatabase, url.PathEscape(docID))
+
+	data, err := json.Marshal(replication)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal replication: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ReplicatorService", "CreateReplication"}, http.MethodPut, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replication: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to create replication: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var docResp DocumentResponse
+	if err := json.Unmarshal(body, &docResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &docResp, nil
+}
+
+// GetReplication retrieves a replication document.
+// GET /_replicator/{docid}
+func (s *ReplicatorService) GetReplication(ctx context.Context, docID string, opts ...RequestOption) (*ReplicationDocument, error) {
+	path := fmt.Sprintf("/%s/%s", ReplicatorDatabase, url.PathEscape(docID))
+
+	resp, err := s.client.doRequest(ctx, operation{"ReplicatorService", "GetReplication"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replication: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("replication not found: %s", docID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get replication: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var doc ReplicationDocument
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal replication: %w", err)
+	}
+
+	return &doc, nil
+}
+
+// DeleteReplication deletes a replication document, cancelling the replication.
+// DELETE /_replicator/{docid}
+func (s *ReplicatorService) DeleteReplication(ctx context.Context, docID, rev string, opts ...RequestOption) (*DocumentResponse, error) {
+	path := fmt.Sprintf("/%s/%s?rev=%s", ReplicatorDatabase, url.PathEscape(docID), url.QueryEscape(rev))
+
+	resp, err := s.client.doRequest(ctx, operation{"ReplicatorService", "DeleteReplication"}, http.MethodDelete, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete replication: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to delete replication: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var docResp DocumentResponse
+	if err := json.Unmarshal(body, &docResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &docResp, nil
+}
+
+// ListReplications retrieves all replication documents.
+func (s *ReplicatorService) ListReplications(ctx context.Context, opts ...RequestOption) ([]ReplicationDocument, error) {
+	path := fmt.Sprintf("/%s/_all_docs?include_docs=true", ReplicatorDatabase)
+
+	resp, err := s.client.doRequest(ctx, operation{"ReplicatorService", "ListReplications"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replications: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to list replications: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var result struct {
+		Rows []struct {
+			ID  string              `json:"id"`
+			Doc ReplicationDocument `json:"doc"`
+		} `json:"rows"`
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	docs := make([]ReplicationDocument, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		// Skip design documents.
+		if strings.HasPrefix(row.ID, "_design/") {
+			continue
+		}
+		docs = append(docs, row.Doc)
+	}
+
+	return docs, nil
+}
+
+// WatchReplication polls the scheduler state of a replication document every
+// interval and calls onChange whenever the state changes, e.g. from
+// "initializing" to "running" or "crashing". It returns the final state once
+// the replication is "completed" or "failed", or when ctx is done.
+//
+// Continuous replications never complete; cancel ctx to stop watching them.
+// A non-positive interval defaults to 5s.
+func (s *ReplicatorService) WatchReplication(ctx context.Context, docID string, interval time.Duration, onChange func(*SchedulerDoc), opts ...RequestOption) (*SchedulerDoc, error) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	server := &ServerService{client: s.client}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *SchedulerDoc
+	for {
+		doc, err := server.GetSchedulerDoc(ctx, ReplicatorDatabase, docID, opts...)
+		if err != nil {
+			return last, err
+		}
+
+		if last == nil || last.State != doc.State {
+			if onChange != nil {
+				onChange(doc)
+			}
+		}
+		last = doc
+
+		switch doc.State {
+		case ReplicationStateCompleted, ReplicationStateFailed:
+			return doc, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
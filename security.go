@@ -36,7 +36,7 @@ type Members struct {
 func (s *SecurityService) GetSecurity(ctx context.Context, dbName string, opts ...RequestOption) (*SecurityObject, error) {
 	path := fmt.Sprintf("/%s/_security", url.PathEscape(dbName))
 
-	resp, err := s.client.doRequest(ctx, http.MethodGet, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"SecurityService", "GetSecurity"}, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get security: %w", err)
 	}
@@ -72,7 +72,7 @@ func (s *SecurityService) SetSecurity(ctx context.Context, dbName string, securi
 		return fmt.Errorf("failed to marshal security: %w", err)
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodPut, path, bytes.NewReader(data), opts...)
+	resp, err := s.client.doRequest(ctx, operation{"SecurityService", "SetSecurity"}, http.MethodPut, path, bytes.NewReader(data), opts...)
 	if err != nil {
 		return fmt.Errorf("failed to set security: %w", err)
 	}
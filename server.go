@@ -1,12 +1,15 @@
 package couchdb
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
 // ServerService provides methods for server-level operations.
@@ -34,7 +37,7 @@ func (s *ServerService) GetUUIDs(ctx context.Context, count int, opts ...Request
 		path = fmt.Sprintf("%s?%s", path, query.Encode())
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodGet, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetUUIDs"}, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get UUIDs: %w", err)
 	}
@@ -60,3 +63,680 @@ func (s *ServerService) GetUUIDs(ctx context.Context, count int, opts ...Request
 
 	return &uuidsResp, nil
 }
+
+// ServerVendor represents the vendor information of a CouchDB server.
+type ServerVendor struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// ServerInfo represents the response from the server root endpoint.
+type ServerInfo struct {
+	CouchDB  string       `json:"couchdb"`
+	Version  string       `json:"version"`
+	GitSHA   string       `json:"git_sha"`
+	UUID     string       `json:"uuid"`
+	Features []string     `json:"features"`
+	Vendor   ServerVendor `json:"vendor"`
+}
+
+// GetServerInfo returns meta information about the server.
+// GET /
+func (s *ServerService) GetServerInfo(ctx context.Context, opts ...RequestOption) (*ServerInfo, error) {
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetServerInfo"}, http.MethodGet, "/", nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get server info: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var info ServerInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal server info: %w", err)
+	}
+
+	return &info, nil
+}
+
+// UpResponse represents the response from the _up endpoint.
+type UpResponse struct {
+	Status string         `json:"status"` // "ok", "maintenance_mode" or "nolb"
+	Seeds  map[string]any `json:"seeds,omitempty"`
+}
+
+// Up checks whether the server is up, running, and ready to respond to requests.
+// GET /_up
+// If the server is not ready, the decoded status is returned along with an error.
+func (s *ServerService) Up(ctx context.Context, opts ...RequestOption) (*UpResponse, error) {
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "Up"}, http.MethodGet, "/_up", nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check server status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var up UpResponse
+	if err := json.Unmarshal(body, &up); err != nil {
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return &up, fmt.Errorf("server is not up: %s", up.Status)
+	}
+
+	return &up, nil
+}
+
+// VersionsJavaScriptEngine represents the JavaScript engine used by a node.
+type VersionsJavaScriptEngine struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// VersionsErlang represents the Erlang runtime used by a node.
+type VersionsErlang struct {
+	Version         string   `json:"version"`
+	SupportedHashes []string `json:"supported_hashes"`
+}
+
+// VersionsCollationDriver represents the collation library used by a node.
+type VersionsCollationDriver struct {
+	Name                      string `json:"name"`
+	LibraryVersion            string `json:"library_version"`
+	CollatorVersion           string `json:"collator_version"`
+	CollationAlgorithmVersion string `json:"collation_algorithm_version"`
+}
+
+// NodeVersions represents the response from the _versions endpoint.
+type NodeVersions struct {
+	JavaScriptEngine VersionsJavaScriptEngine `json:"javascript_engine"`
+	Erlang           VersionsErlang           `json:"erlang"`
+	CollationDriver  VersionsCollationDriver  `json:"collation_driver"`
+}
+
+// GetVersions returns the versions of the runtime components of a node.
+// GET /_node/{node-name}/_versions
+// Use "_local" for the node handling the request.
+func (s *ServerService) GetVersions(ctx context.Context, nodeName string, opts ...RequestOption) (*NodeVersions, error) {
+	path := fmt.Sprintf("/_node/%s/_versions", url.PathEscape(nodeName))
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetVersions"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get versions: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get versions: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var versions NodeVersions
+	if err := json.Unmarshal(body, &versions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal versions: %w", err)
+	}
+
+	return &versions, nil
+}
+
+// AllDbsOptions represents options for the _all_dbs endpoint.
+type AllDbsOptions struct {
+	Descending bool   `url:"descending,omitempty"`
+	EndKey     string `url:"endkey,omitempty"`
+	Limit      int    `url:"limit,omitempty"`
+	Skip       int    `url:"skip,omitempty"`
+	StartKey   string `url:"startkey,omitempty"`
+}
+
+// AllDbs returns the names of all databases on the server.
+// GET /_all_dbs
+func (s *ServerService) AllDbs(ctx context.Context, options *AllDbsOptions, opts ...RequestOption) ([]string, error) {
+	path := "/_all_dbs"
+
+	// Add query parameters if options provided
+	if options != nil {
+		query := url.Values{}
+		if options.Descending {
+			query.Set("descending", "true")
+		}
+		if options.EndKey != "" {
+			endKeyJSON, _ := json.Marshal(options.EndKey)
+			query.Set("endkey", string(endKeyJSON))
+		}
+		if options.Limit > 0 {
+			query.Set("limit", fmt.Sprintf("%d", options.Limit))
+		}
+		if options.Skip > 0 {
+			query.Set("skip", fmt.Sprintf("%d", options.Skip))
+		}
+		if options.StartKey != "" {
+			startKeyJSON, _ := json.Marshal(options.StartKey)
+			query.Set("startkey", string(startKeyJSON))
+		}
+		if len(query) > 0 {
+			path = fmt.Sprintf("%s?%s", path, query.Encode())
+		}
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "AllDbs"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to list databases: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var dbs []string
+	if err := json.Unmarshal(body, &dbs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return dbs, nil
+}
+
+// DbsInfoItem represents a single database in a _dbs_info response.
+// Error is set instead of Info if the database could not be found.
+type DbsInfoItem struct {
+	Key   string        `json:"key"`
+	Info  *DatabaseInfo `json:"info,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// DbsInfo returns information about several databases in a single request.
+// POST /_dbs_info
+func (s *ServerService) DbsInfo(ctx context.Context, dbNames []string, opts ...RequestOption) ([]DbsInfoItem, error) {
+	reqBody := map[string]any{
+		"keys": dbNames,
+	}
+
+	data, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal keys: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "DbsInfo"}, http.MethodPost, "/_dbs_info", bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get databases info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get databases info: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var items []DbsInfoItem
+	if err := json.Unmarshal(body, &items); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return items, nil
+}
+
+// Active task types reported by the _active_tasks endpoint.
+const (
+	ActiveTaskTypeIndexer            = "indexer"
+	ActiveTaskTypeDatabaseCompaction = "database_compaction"
+	ActiveTaskTypeViewCompaction     = "view_compaction"
+	ActiveTaskTypeReplication        = "replication"
+)
+
+// IndexerTask represents the details of an indexer task.
+type IndexerTask struct {
+	Database       string `json:"database"`
+	DesignDocument string `json:"design_document"`
+	ChangesDone    int    `json:"changes_done"`
+	TotalChanges   int    `json:"total_changes"`
+	Progress       int    `json:"progress"`
+}
+
+// DatabaseCompactionTask represents the details of a database compaction task.
+type DatabaseCompactionTask struct {
+	Database     string `json:"database"`
+	ChangesDone  int    `json:"changes_done"`
+	TotalChanges int    `json:"total_changes"`
+	Progress     int    `json:"progress"`
+	Phase        string `json:"phase,omitempty"`
+}
+
+// ViewCompactionTask represents the details of a view compaction task.
+type ViewCompactionTask struct {
+	Database       string `json:"database"`
+	DesignDocument string `json:"design_document"`
+	ChangesDone    int    `json:"changes_done"`
+	TotalChanges   int    `json:"total_changes"`
+	Progress       int    `json:"progress"`
+	Phase          string `json:"phase,omitempty"`
+	View           int    `json:"view,omitempty"`
+}
+
+// ReplicationTask represents the details of a replication task.
+type ReplicationTask struct {
+	ReplicationID         string `json:"replication_id"`
+	DocID                 string `json:"doc_id,omitempty"`
+	Database              string `json:"database,omitempty"`
+	User                  string `json:"user,omitempty"`
+	Source                string `json:"source"`
+	Target                string `json:"target"`
+	Continuous            bool   `json:"continuous"`
+	DocsRead              int    `json:"docs_read"`
+	DocsWritten           int    `json:"docs_written"`
+	DocWriteFailures      int    `json:"doc_write_failures"`
+	MissingRevisionsFound int    `json:"missing_revisions_found"`
+	RevisionsChecked      int    `json:"revisions_checked"`
+	ChangesPending        *int   `json:"changes_pending,omitempty"`
+	CheckpointInterval    int    `json:"checkpoint_interval,omitempty"`
+	CheckpointedSourceSeq any    `json:"checkpointed_source_seq,omitempty"`
+	SourceSeq             any    `json:"source_seq,omitempty"`
+	ThroughSeq            any    `json:"through_seq,omitempty"`
+}
+
+// ActiveTask represents a task running on the server.
+// Exactly one of the typed detail fields is set, according to Type.
+type ActiveTask struct {
+	Type          string `json:"type"`
+	Node          string `json:"node"`
+	PID           string `json:"pid"`
+	ProcessStatus string `json:"process_status,omitempty"`
+	StartedOn     int64  `json:"started_on"`
+	UpdatedOn     int64  `json:"updated_on"`
+
+	Indexer            *IndexerTask            `json:"-"`
+	DatabaseCompaction *DatabaseCompactionTask `json:"-"`
+	ViewCompaction     *ViewCompactionTask     `json:"-"`
+	Replication        *ReplicationTask        `json:"-"`
+}
+
+// UnmarshalJSON decodes the common task fields and the details matching the task type.
+func (t *ActiveTask) UnmarshalJSON(data []byte) error {
+	type common ActiveTask
+	if err := json.Unmarshal(data, (*common)(t)); err != nil {
+		return err
+	}
+
+	var details any
+	switch t.Type {
+	case ActiveTaskTypeIndexer:
+		t.Indexer = &IndexerTask{}
+		details = t.Indexer
+	case ActiveTaskTypeDatabaseCompaction:
+		t.DatabaseCompaction = &DatabaseCompactionTask{}
+		details = t.DatabaseCompaction
+	case ActiveTaskTypeViewCompaction:
+		t.ViewCompaction = &ViewCompactionTask{}
+		details = t.ViewCompaction
+	case ActiveTaskTypeReplication:
+		t.Replication = &ReplicationTask{}
+		details = t.Replication
+	default:
+		return nil
+	}
+
+	return json.Unmarshal(data, details)
+}
+
+// Database returns the database the task operates on, if any.
+func (t *ActiveTask) Database() string {
+	switch {
+	case t.Indexer != nil:
+		return t.Indexer.Database
+	case t.DatabaseCompaction != nil:
+		return t.DatabaseCompaction.Database
+	case t.ViewCompaction != nil:
+		return t.ViewCompaction.Database
+	case t.Replication != nil:
+		return t.Replication.Database
+	}
+	return ""
+}
+
+// Progress returns the completion percentage of the task, or -1 if the task
+// does not report progress.
+func (t *ActiveTask) Progress() int {
+	switch {
+	case t.Indexer != nil:
+		return t.Indexer.Progress
+	case t.DatabaseCompaction != nil:
+		return t.DatabaseCompaction.Progress
+	case t.ViewCompaction != nil:
+		return t.ViewCompaction.Progress
+	}
+	return -1
+}
+
+// GetActiveTasks lists the tasks running on the server.
+// GET /_active_tasks
+func (s *ServerService) GetActiveTasks(ctx context.Context, opts ...RequestOption) ([]ActiveTask, error) {
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetActiveTasks"}, http.MethodGet, "/_active_tasks", nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active tasks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get active tasks: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var tasks []ActiveTask
+	if err := json.Unmarshal(body, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal active tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// WaitForActiveTasks polls _active_tasks every interval until no task matched
+// by match is running anymore. progress, if not nil, is called with every
+// matching task on each poll. A non-positive interval defaults to 1s.
+//
+// Tasks disappear from _active_tasks once they complete, so WaitForActiveTasks
+// returns immediately if no matching task has started yet.
+// Example usage:
+//
+//	err := server.WaitForActiveTasks(ctx, func(t ActiveTask) bool {
+//		return t.Indexer != nil && t.Indexer.Database == "orders"
+//	}, time.Second, func(t ActiveTask) {
+//		log.Printf("%s: %d%%", t.Indexer.DesignDocument, t.Progress())
+//	})
+func (s *ServerService) WaitForActiveTasks(ctx context.Context, match func(ActiveTask) bool, interval time.Duration, progress func(ActiveTask), opts ...RequestOption) error {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		tasks, err := s.GetActiveTasks(ctx, opts...)
+		if err != nil {
+			return err
+		}
+
+		running := false
+		for _, task := range tasks {
+			if !match(task) {
+				continue
+			}
+			running = true
+			if progress != nil {
+				progress(task)
+			}
+		}
+
+		if !running {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SchedulerListOptions represents options for the _scheduler endpoints.
+type SchedulerListOptions struct {
+	Limit  int      `url:"limit,omitempty"`
+	Skip   int      `url:"skip,omitempty"`
+	States []string `url:"states,omitempty"` // Only for scheduler docs, e.g. "running", "failed".
+}
+
+// SchedulerJobEvent represents an entry in a replication job's history.
+type SchedulerJobEvent struct {
+	Timestamp string `json:"timestamp"`
+	Type      string `json:"type"` // "added", "started", "crashed", "stopped"
+	Reason    string `json:"reason,omitempty"`
+}
+
+// SchedulerInfo represents replication statistics reported by the scheduler.
+type SchedulerInfo struct {
+	RevisionsChecked      int    `json:"revisions_checked"`
+	MissingRevisionsFound int    `json:"missing_revisions_found"`
+	DocsRead              int    `json:"docs_read"`
+	DocsWritten           int    `json:"docs_written"`
+	ChangesPending        *int   `json:"changes_pending"`
+	DocWriteFailures      int    `json:"doc_write_failures"`
+	CheckpointedSourceSeq any    `json:"checkpointed_source_seq,omitempty"`
+	SourceSeq             any    `json:"source_seq,omitempty"`
+	ThroughSeq            any    `json:"through_seq,omitempty"`
+	Error                 string `json:"error,omitempty"`
+}
+
+// SchedulerJob represents a replication job known to the scheduler.
+type SchedulerJob struct {
+	ID        string              `json:"id"`
+	Database  string              `json:"database,omitempty"`
+	DocID     string              `json:"doc_id,omitempty"`
+	PID       string              `json:"pid,omitempty"`
+	Node      string              `json:"node"`
+	Source    string              `json:"source"`
+	Target    string              `json:"target"`
+	User      string              `json:"user,omitempty"`
+	StartTime string              `json:"start_time"`
+	History   []SchedulerJobEvent `json:"history"`
+	Info      *SchedulerInfo      `json:"info,omitempty"`
+}
+
+// SchedulerJobsResponse represents the response from _scheduler/jobs.
+type SchedulerJobsResponse struct {
+	TotalRows int            `json:"total_rows"`
+	Offset    int            `json:"offset"`
+	Jobs      []SchedulerJob `json:"jobs"`
+}
+
+// Replication states reported by the scheduler and in _replication_state.
+const (
+	ReplicationStateInitializing = "initializing"
+	ReplicationStateRunning      = "running"
+	ReplicationStatePending      = "pending"
+	ReplicationStateCrashing     = "crashing"
+	ReplicationStateError        = "error"
+	ReplicationStateFailed       = "failed"
+	ReplicationStateCompleted    = "completed"
+)
+
+// SchedulerDoc represents the state of a replication document.
+type SchedulerDoc struct {
+	Database    string         `json:"database"`
+	DocID       string         `json:"doc_id"`
+	ID          string         `json:"id,omitempty"`
+	Node        string         `json:"node,omitempty"`
+	Source      string         `json:"source"`
+	Target      string         `json:"target"`
+	State       string         `json:"state"`
+	ErrorCount  int            `json:"error_count"`
+	LastUpdated string         `json:"last_updated"`
+	StartTime   string         `json:"start_time"`
+	Info        *SchedulerInfo `json:"info,omitempty"`
+}
+
+// SchedulerDocsResponse represents the response from _scheduler/docs.
+type SchedulerDocsResponse struct {
+	TotalRows int            `json:"total_rows"`
+	Offset    int            `json:"offset"`
+	Docs      []SchedulerDoc `json:"docs"`
+}
+
+// GetSchedulerJobs lists the replication jobs known to the scheduler.
+// GET /_scheduler/jobs
+func (s *ServerService) GetSchedulerJobs(ctx context.Context, options *SchedulerListOptions, opts ...RequestOption) (*SchedulerJobsResponse, error) {
+	path := "/_scheduler/jobs"
+
+	// Add query parameters if options provided
+	if options != nil {
+		query := url.Values{}
+		if options.Limit > 0 {
+			query.Set("limit", fmt.Sprintf("%d", options.Limit))
+		}
+		if options.Skip > 0 {
+			query.Set("skip", fmt.Sprintf("%d", options.Skip))
+		}
+		if len(query) > 0 {
+			path = fmt.Sprintf("%s?%s", path, query.Encode())
+		}
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetSchedulerJobs"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler jobs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get scheduler jobs: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var jobsResp SchedulerJobsResponse
+	if err := json.Unmarshal(body, &jobsResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &jobsResp, nil
+}
+
+// GetSchedulerDocs lists the states of replication documents.
+// GET /_scheduler/docs
+func (s *ServerService) GetSchedulerDocs(ctx context.Context, options *SchedulerListOptions, opts ...RequestOption) (*SchedulerDocsResponse, error) {
+	path := "/_scheduler/docs"
+
+	// Add query parameters if options provided
+	if options != nil {
+		query := url.Values{}
+		if options.Limit > 0 {
+			query.Set("limit", fmt.Sprintf("%d", options.Limit))
+		}
+		if options.Skip > 0 {
+			query.Set("skip", fmt.Sprintf("%d", options.Skip))
+		}
+		if len(options.States) > 0 {
+			query.Set("states", strings.Join(options.States, ","))
+		}
+		if len(query) > 0 {
+			path = fmt.Sprintf("%s?%s", path, query.Encode())
+		}
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetSchedulerDocs"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler docs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get scheduler docs: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var docsResp SchedulerDocsResponse
+	if err := json.Unmarshal(body, &docsResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &docsResp, nil
+}
+
+// GetSchedulerDoc returns the state of a single replication document.
+// GET /_scheduler/docs/{replicator_db}/{docid}
+func (s *ServerService) GetSchedulerDoc(ctx context.Context, replicatorDB, docID string, opts ...RequestOption) (*SchedulerDoc, error) {
+	path := fmt.Sprintf("/_scheduler/docs/%s/%s", url.PathEscape(replicatorDB), url.PathEscape(docID))
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetSchedulerDoc"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler doc: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("replication document not found: %s/%s", replicatorDB, docID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get scheduler doc: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var doc SchedulerDoc
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scheduler doc: %w", err)
+	}
+
+	return &doc, nil
+}
@@ -58,7 +58,7 @@ func (s *SessionService) Login(ctx context.Context, username, password string, o
 		return nil, nil, fmt.Errorf("failed to marshal credentials: %w", err)
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodPost, "/_session", bytes.NewReader(data), opts...)
+	resp, err := s.client.doRequest(ctx, operation{"SessionService", "Login"}, http.MethodPost, "/_session", bytes.NewReader(data), opts...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to login: %w", err)
 	}
@@ -96,7 +96,7 @@ func (s *SessionService) Login(ctx context.Context, username, password string, o
 
 // Logout ends the current session.
 func (s *SessionService) Logout(ctx context.Context, opts ...RequestOption) error {
-	resp, err := s.client.doRequest(ctx, http.MethodDelete, "/_session", nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"SessionService", "Logout"}, http.MethodDelete, "/_session", nil, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to logout: %w", err)
 	}
@@ -120,7 +120,7 @@ func (s *SessionService) Logout(ctx context.Context, opts ...RequestOption) erro
 
 // GetSession retrieves information about the current session.
 func (s *SessionService) GetSession(ctx context.Context, opts ...RequestOption) (*SessionInfo, error) {
-	resp, err := s.client.doRequest(ctx, http.MethodGet, "/_session", nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"SessionService", "GetSession"}, http.MethodGet, "/_session", nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
@@ -0,0 +1,505 @@
+package couchdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// DatabaseShards maps the shard ranges of a database to the nodes holding a
+// copy of each range.
+type DatabaseShards struct {
+	Shards map[string][]string `json:"shards"`
+}
+
+// DocumentShard represents the shard range a document belongs to and the nodes
+// holding it.
+type DocumentShard struct {
+	Range string   `json:"range"`
+	Nodes []string `json:"nodes"`
+}
+
+// GetShards returns the shard ranges of a database and the nodes holding
+// each of them.
+// GET /{db}/_shards
+func (s *DatabaseService) GetShards(ctx context.Context, dbName string, opts ...RequestOption) (*DatabaseShards, error) {
+	path := fmt.Sprintf("/%s/_shards", url.PathEscape(dbName))
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "GetShards"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shards: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get shards: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var shards DatabaseShards
+	if err := json.Unmarshal(body, &shards); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal shards: %w", err)
+	}
+
+	return &shards, nil
+}
+
+// GetDocumentShard returns the shard range a document ID maps to and the
+// nodes holding it. The document does not need to exist.
+// GET /{db}/_shards/{docid}
+func (s *DatabaseService) GetDocumentShard(ctx context.Context, dbName, docID string, opts ...RequestOption) (*DocumentShard, error) {
+	path := fmt.Sprintf("/%s/_shards/%s", url.PathEscape(dbName), url.PathEscape(docID))
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "GetDocumentShard"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document shard: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get document shard: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var shard DocumentShard
+	if err := json.Unmarshal(body, &shard); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal shard: %w", err)
+	}
+
+	return &shard, nil
+}
+
+// SyncShards forces the internal replication between the copies of every
+// shard of a database, e.g. after adding a node or moving shards.
+// POST /{db}/_sync_shards
+func (s *DatabaseService) SyncShards(ctx context.Context, dbName string, opts ...RequestOption) (*DatabaseResponse, error) {
+	path := fmt.Sprintf("/%s/_sync_shards", url.PathEscape(dbName))
+
+	resp, err := s.client.doRequest(ctx, operation{"DatabaseService", "SyncShards"}, http.MethodPost, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync shards: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to sync shards: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var dbResp DatabaseResponse
+	if err := json.Unmarshal(body, &dbResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &dbResp, nil
+}
+
+// Resharding states, used both for the resharding subsystem of the cluster
+// and for individual jobs.
+const (
+	ReshardStateRunning   = "running"
+	ReshardStateStopped   = "stopped"
+	ReshardStateNew       = "new"       // Jobs only
+	ReshardStateCompleted = "completed" // Jobs only
+	ReshardStateFailed    = "failed"    // Jobs only
+)
+
+// ReshardState represents the state of the resharding subsystem or of a
+// resharding job.
+type ReshardState struct {
+	State  string `json:"state"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ReshardSummary represents the state of resharding on the cluster and the
+// number of jobs per state.
+type ReshardSummary struct {
+	State       string `json:"state"`
+	StateReason string `json:"state_reason"`
+	Total       int    `json:"total"`
+	Running     int    `json:"running"`
+	Completed   int    `json:"completed"`
+	Failed      int    `json:"failed"`
+	Stopped     int    `json:"stopped"`
+}
+
+// ReshardJobEvent represents an entry in a resharding job's history.
+type ReshardJobEvent struct {
+	Timestamp string `json:"timestamp"`
+	Type      string `json:"type"`
+	Detail    any    `json:"detail"`
+}
+
+// ReshardJob represents a shard splitting job.
+type ReshardJob struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	JobState   string            `json:"job_state"`
+	SplitState string            `json:"split_state"`
+	Node       string            `json:"node"`
+	Source     string            `json:"source"`
+	Target     []string          `json:"target"`
+	StartTime  string            `json:"start_time"`
+	UpdateTime string            `json:"update_time"`
+	StateInfo  map[string]any    `json:"state_info"`
+	History    []ReshardJobEvent `json:"history"`
+}
+
+// ReshardJobsResponse represents the response from /_reshard/jobs.
+type ReshardJobsResponse struct {
+	Jobs      []ReshardJob `json:"jobs"`
+	Offset    int          `json:"offset"`
+	TotalRows int          `json:"total_rows"`
+}
+
+// CreateReshardJobRequest describes the shards to split. Either Shard or DB
+// must be set; Node and Range narrow a DB down to some of its shard copies.
+type CreateReshardJobRequest struct {
+	Type  string `json:"type"` // Defaults to "split"
+	DB    string `json:"db,omitempty"`
+	Node  string `json:"node,omitempty"`
+	Range string `json:"range,omitempty"`
+	Shard string `json:"shard,omitempty"`
+}
+
+// ReshardJobResult represents a job created, or refused, by CreateReshardJobs.
+type ReshardJobResult struct {
+	OK     bool   `json:"ok"`
+	ID     string `json:"id,omitempty"`
+	Node   string `json:"node,omitempty"`
+	Shard  string `json:"shard,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// GetReshardSummary returns the state of resharding on the cluster and job
+// counts.
+// GET /_reshard
+func (s *ServerService) GetReshardSummary(ctx context.Context, opts ...RequestOption) (*ReshardSummary, error) {
+	path := "/_reshard"
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetReshardSummary"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reshard summary: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get reshard summary: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var summary ReshardSummary
+	if err := json.Unmarshal(body, &summary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reshard summary: %w", err)
+	}
+
+	return &summary, nil
+}
+
+// GetReshardState returns whether resharding is running or stopped on the
+// cluster.
+// GET /_reshard/state
+func (s *ServerService) GetReshardState(ctx context.Context, opts ...RequestOption) (*ReshardState, error) {
+	path := "/_reshard/state"
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetReshardState"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reshard state: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get reshard state: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var state ReshardState
+	if err := json.Unmarshal(body, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reshard state: %w", err)
+	}
+
+	return &state, nil
+}
+
+// SetReshardState starts or stops resharding on the whole cluster. state is
+// ReshardStateRunning or ReshardStateStopped; reason is optional.
+// PUT /_reshard/state
+func (s *ServerService) SetReshardState(ctx context.Context, state, reason string, opts ...RequestOption) error {
+	path := "/_reshard/state"
+
+	data, err := json.Marshal(ReshardState{State: state, Reason: reason})
+	if err != nil {
+		return fmt.Errorf("failed to marshal reshard state: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "SetReshardState"}, http.MethodPut, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return fmt.Errorf("failed to set reshard state: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return fmt.Errorf("failed to set reshard state: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	return nil
+}
+
+// GetReshardJobs lists the resharding jobs.
+// GET /_reshard/jobs
+func (s *ServerService) GetReshardJobs(ctx context.Context, opts ...RequestOption) (*ReshardJobsResponse, error) {
+	path := "/_reshard/jobs"
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetReshardJobs"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reshard jobs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get reshard jobs: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var jobs ReshardJobsResponse
+	if err := json.Unmarshal(body, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reshard jobs: %w", err)
+	}
+
+	return &jobs, nil
+}
+
+// GetReshardJob returns a resharding job.
+// GET /_reshard/jobs/{jobid}
+func (s *ServerService) GetReshardJob(ctx context.Context, jobID string, opts ...RequestOption) (*ReshardJob, error) {
+	path := fmt.Sprintf("/_reshard/jobs/%s", url.PathEscape(jobID))
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetReshardJob"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reshard job: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get reshard job: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var job ReshardJob
+	if err := json.Unmarshal(body, &job); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reshard job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// CreateReshardJobs creates jobs that split the matching shard copies in two.
+// The result lists one entry per job, including refused ones.
+// POST /_reshard/jobs
+// Example usage:
+//
+//	results, err := server.CreateReshardJobs(ctx, &CreateReshardJobRequest{
+//		DB:    "orders",
+//		Range: "00000000-7fffffff",
+//	})
+func (s *ServerService) CreateReshardJobs(ctx context.Context, req *CreateReshardJobRequest, opts ...RequestOption) ([]ReshardJobResult, error) {
+	path := "/_reshard/jobs"
+
+	job := *req
+	if job.Type == "" {
+		job.Type = "split"
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reshard job: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "CreateReshardJobs"}, http.MethodPost, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reshard jobs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to create reshard jobs: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var results []ReshardJobResult
+	if err := json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reshard jobs: %w", err)
+	}
+
+	return results, nil
+}
+
+// DeleteReshardJob stops and removes a resharding job.
+// DELETE /_reshard/jobs/{jobid}
+func (s *ServerService) DeleteReshardJob(ctx context.Context, jobID string, opts ...RequestOption) error {
+	path := fmt.Sprintf("/_reshard/jobs/%s", url.PathEscape(jobID))
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "DeleteReshardJob"}, http.MethodDelete, path, nil, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to delete reshard job: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return fmt.Errorf("failed to delete reshard job: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	return nil
+}
+
+// GetReshardJobState returns the state of a resharding job.
+// GET /_reshard/jobs/{jobid}/state
+func (s *ServerService) GetReshardJobState(ctx context.Context, jobID string, opts ...RequestOption) (*ReshardState, error) {
+	path := fmt.Sprintf("/_reshard/jobs/%s/state", url.PathEscape(jobID))
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "GetReshardJobState"}, http.MethodGet, path, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reshard job state: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return nil, fmt.Errorf("failed to get reshard job state: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	var state ReshardState
+	if err := json.Unmarshal(body, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reshard job state: %w", err)
+	}
+
+	return &state, nil
+}
+
+// SetReshardJobState stops or resumes a resharding job. state is
+// ReshardStateRunning or ReshardStateStopped; reason is optional.
+// PUT /_reshard/jobs/{jobid}/state
+func (s *ServerService) SetReshardJobState(ctx context.Context, jobID string, state, reason string, opts ...RequestOption) error {
+	path := fmt.Sprintf("/_reshard/jobs/%s/state", url.PathEscape(jobID))
+
+	data, err := json.Marshal(ReshardState{State: state, Reason: reason})
+	if err != nil {
+		return fmt.Errorf("failed to marshal reshard state: %w", err)
+	}
+
+	resp, err := s.client.doRequest(ctx, operation{"ServerService", "SetReshardJobState"}, http.MethodPut, path, bytes.NewReader(data), opts...)
+	if err != nil {
+		return fmt.Errorf("failed to set reshard job state: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp ErrorResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+		}
+		return fmt.Errorf("failed to set reshard job state: %s - %s", errResp.Error, errResp.Reason)
+	}
+
+	return nil
+}
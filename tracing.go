@@ -0,0 +1,42 @@
+package couchdb
+
+import (
+	"context"
+	"net/http"
+)
+
+// Tracer starts spans around CouchDB calls.
+// See the otelcouchdb package for an OpenTelemetry implementation.
+type Tracer interface {
+	// Start begins a span for the request described by info. The returned
+	// context is used for the outgoing request.
+	Start(ctx context.Context, info RequestInfo) (context.Context, Span)
+}
+
+// Span represents a single traced CouchDB call.
+type Span interface {
+	// End completes the span. It is called once the response body has been
+	// fully read or closed, so for changes feeds and other streaming calls the
+	// span covers the entire body read.
+	End(result RequestResult)
+}
+
+// WithTracer reports every request issued by the client to the given Tracer.
+// Tracing is installed as a middleware; see WithMiddleware for ordering.
+func WithTracer(tracer Tracer) ClientOption {
+	return WithMiddleware(tracingMiddleware(tracer))
+}
+
+func tracingMiddleware(tracer Tracer) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			info, _ := RequestInfoFromContext(req.Context())
+
+			ctx, span := tracer.Start(req.Context(), info)
+			req = req.WithContext(ctx)
+
+			resp, err := next.RoundTrip(req)
+			return observeResponse(req, resp, err, span.End)
+		})
+	}
+}
@@ -60,7 +60,7 @@ func (s *UserService) CreateUser(ctx context.Context, name, password string, rol
 		return nil, fmt.Errorf("failed to marshal user: %w", err)
 	}
 
-	resp, err := s.client.doRequest(ctx, http.MethodPost, "/_users", bytes.NewReader(data), opts...)
+	resp, err := s.client.doRequest(ctx, operation{"UserService", "CreateUser"}, http.MethodPost, "/_users", bytes.NewReader(data), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
@@ -92,7 +92,7 @@ func (s *UserService) GetUser(ctx context.Context, name string, opts ...RequestO
 	docID := fmt.Sprintf("org.couchdb.user:%s", name)
 	path := fmt.Sprintf("/_users/%s", url.PathEscape(docID))
 
-	resp, err := s.client.doRequest(ctx, http.MethodGet, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"UserService", "GetUser"}, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -149,7 +149,7 @@ func (s *UserService) UpdateUser(ctx context.Context, name, rev string, password
 	}
 
 	path := fmt.Sprintf("/_users/%s", url.PathEscape(docID))
-	resp, err := s.client.doRequest(ctx, http.MethodPut, path, bytes.NewReader(data), opts...)
+	resp, err := s.client.doRequest(ctx, operation{"UserService", "UpdateUser"}, http.MethodPut, path, bytes.NewReader(data), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
@@ -181,7 +181,7 @@ func (s *UserService) DeleteUser(ctx context.Context, name, rev string, opts ...
 	docID := fmt.Sprintf("org.couchdb.user:%s", name)
 	path := fmt.Sprintf("/_users/%s?rev=%s", url.PathEscape(docID), url.QueryEscape(rev))
 
-	resp, err := s.client.doRequest(ctx, http.MethodDelete, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"UserService", "DeleteUser"}, http.MethodDelete, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -212,7 +212,7 @@ func (s *UserService) DeleteUser(ctx context.Context, name, rev string, opts ...
 func (s *UserService) ListUsers(ctx context.Context, opts ...RequestOption) ([]User, error) {
 	path := "/_users/_all_docs?include_docs=true"
 
-	resp, err := s.client.doRequest(ctx, http.MethodGet, path, nil, opts...)
+	resp, err := s.client.doRequest(ctx, operation{"UserService", "ListUsers"}, http.MethodGet, path, nil, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list users: %w", err)
 	}
@@ -296,7 +296,7 @@ func (s *UserService) UpdateRoles(ctx context.Context, name, rev string, roles [
 	}
 
 	path := fmt.Sprintf("/_users/%s", url.PathEscape(docID))
-	resp, err := s.client.doRequest(ctx, http.MethodPut, path, bytes.NewReader(data), opts...)
+	resp, err := s.client.doRequest(ctx, operation{"UserService", "UpdateRoles"}, http.MethodPut, path, bytes.NewReader(data), opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update roles: %w", err)
 	}